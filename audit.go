@@ -0,0 +1,192 @@
+package tado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// AuditSink receives an AuditEntry for every mutating request (PUT, POST, DELETE) made by a Client.
+// Audit is called synchronously after the request finished, also when it failed. It can be called from multiple
+// goroutines at the same time.
+type AuditSink interface {
+	Audit(e AuditEntry)
+}
+
+// AuditEntry describes a single mutating request
+type AuditEntry struct {
+	Time   time.Time
+	Actor  string
+	Method string
+	Path   string
+	// HomeID, ZoneID and DeviceSerialNo are parsed from the path, and are empty when the path does not contain them
+	HomeID         int
+	ZoneID         int
+	DeviceSerialNo string
+	// ZoneIDs contains the zones of requests for several zones at once, PostOverlays and DeleteOverlays
+	ZoneIDs []int
+	Body    json.RawMessage
+	// StatusCode is the HTTP status of the response, 0 when no response was received or in dry-run mode
+	StatusCode int
+	Latency    time.Duration
+	Err        error
+	DryRun     bool
+}
+
+// audit sends an entry for the request to the audit sink
+func (c *Client) audit(call *Call, latency time.Duration, err error) {
+	e := AuditEntry{
+		Time:           time.Now().Add(-latency),
+		Actor:          c.username,
+		Method:         call.Method,
+		Path:           call.Path,
+		HomeID:         call.HomeID(),
+		ZoneID:         call.ZoneID(),
+		DeviceSerialNo: call.DeviceSerialNo(),
+		ZoneIDs:        callZoneIDs(call),
+		StatusCode:     call.StatusCode(),
+		Latency:        latency,
+		Err:            err,
+		DryRun:         c.DryRun,
+	}
+	if call.Body != nil {
+		e.Body = bytes.TrimSpace(call.Body)
+	}
+	c.AuditSink.Audit(e)
+}
+
+// callZoneIDs returns the zones of a call for several zones, or nil for other calls
+func callZoneIDs(call *Call) []int {
+	switch in := call.Input.(type) {
+	case *PostOverlaysInput:
+		ids := make([]int, 0, len(in.Overlays))
+		for _, zo := range in.Overlays {
+			ids = append(ids, zo.ZoneID)
+		}
+		return ids
+	case *DeleteOverlaysInput:
+		return append([]int(nil), in.ZoneIDs...)
+	}
+	return nil
+}
+
+// jsonAuditEntry is the JSON representation of an AuditEntry
+type jsonAuditEntry struct {
+	Time           time.Time       `json:"time"`
+	Actor          string          `json:"actor"`
+	Method         string          `json:"method"`
+	Path           string          `json:"path"`
+	HomeID         int             `json:"homeId,omitempty"`
+	ZoneID         int             `json:"zoneId,omitempty"`
+	DeviceSerialNo string          `json:"deviceSerialNo,omitempty"`
+	ZoneIDs        []int           `json:"zoneIds,omitempty"`
+	Body           json.RawMessage `json:"body,omitempty"`
+	StatusCode     int             `json:"status"`
+	LatencyMs      float64         `json:"latencyMs"`
+	Error          string          `json:"error,omitempty"`
+	DryRun         bool            `json:"dryRun,omitempty"`
+}
+
+// JSONLinesAuditSink writes every AuditEntry as a single line of JSON.
+type JSONLinesAuditSink struct {
+	w     io.Writer
+	mutex sync.Mutex
+	err   error
+}
+
+// NewJSONLinesAuditSink returns an AuditSink writing to w, for a file open it with os.O_APPEND.
+func NewJSONLinesAuditSink(w io.Writer) *JSONLinesAuditSink {
+	return &JSONLinesAuditSink{w: w}
+}
+
+// Audit writes e as a line of JSON
+func (s *JSONLinesAuditSink) Audit(e AuditEntry) {
+	je := jsonAuditEntry{
+		Time:           e.Time.UTC(),
+		Actor:          e.Actor,
+		Method:         e.Method,
+		Path:           e.Path,
+		HomeID:         e.HomeID,
+		ZoneID:         e.ZoneID,
+		DeviceSerialNo: e.DeviceSerialNo,
+		ZoneIDs:        e.ZoneIDs,
+		Body:           e.Body,
+		StatusCode:     e.StatusCode,
+		LatencyMs:      float64(e.Latency) / float64(time.Millisecond),
+		DryRun:         e.DryRun,
+	}
+	if e.Err != nil {
+		je.Error = e.Err.Error()
+	}
+	b, err := json.Marshal(je)
+	if err == nil {
+		b = append(b, '\n')
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err == nil {
+		_, err = s.w.Write(b)
+	}
+	if err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the first error that occurred while writing an entry.
+func (s *JSONLinesAuditSink) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+// SlogAuditSink logs every AuditEntry to a slog.Logger.
+type SlogAuditSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogAuditSink returns an AuditSink logging to logger at info level, or to slog.Default() if logger is nil.
+func NewSlogAuditSink(logger *slog.Logger) *SlogAuditSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogAuditSink{logger: logger}
+}
+
+// Audit logs e, requests that failed are logged at error level
+func (s *SlogAuditSink) Audit(e AuditEntry) {
+	attrs := []slog.Attr{
+		slog.String("actor", e.Actor),
+		slog.String("method", e.Method),
+		slog.String("path", e.Path),
+	}
+	if e.HomeID != 0 {
+		attrs = append(attrs, slog.Int("homeId", e.HomeID))
+	}
+	if e.ZoneID != 0 {
+		attrs = append(attrs, slog.Int("zoneId", e.ZoneID))
+	}
+	if e.DeviceSerialNo != "" {
+		attrs = append(attrs, slog.String("deviceSerialNo", e.DeviceSerialNo))
+	}
+	if len(e.ZoneIDs) > 0 {
+		attrs = append(attrs, slog.Any("zoneIds", e.ZoneIDs))
+	}
+	if len(e.Body) > 0 {
+		attrs = append(attrs, slog.String("body", string(e.Body)))
+	}
+	attrs = append(attrs, slog.Int("status", e.StatusCode), slog.Duration("latency", e.Latency))
+	if e.DryRun {
+		attrs = append(attrs, slog.Bool("dryRun", true))
+	}
+	level := slog.LevelInfo
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+		level = slog.LevelError
+	}
+	s.logger.LogAttrs(context.Background(), level, "tado audit", attrs...)
+}
@@ -0,0 +1,135 @@
+package tado
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAuditSink struct {
+	entries []AuditEntry
+}
+
+func (s *testAuditSink) Audit(e AuditEntry) {
+	s.entries = append(s.entries, e)
+}
+
+func TestClient_AuditSink(t *testing.T) {
+	f := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`invalid`))
+		}
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	sink := new(testAuditSink)
+	client.AuditSink = sink
+
+	// GET requests are not audited
+	_, err := client.GetZoneState(&GetZoneStateInput{HomeID: 12345, ZoneID: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, sink.entries)
+
+	_, err = client.DeleteOverlay(&DeleteOverlayInput{HomeID: 12345, ZoneID: 3})
+	assert.NoError(t, err)
+	_, err = client.PutTemperatureOffset(&PutTemperatureOffsetInput{SerialNo: "VA01", Offset: Temperature{Celsius: 1}})
+	assert.Error(t, err)
+
+	if assert.Len(t, sink.entries, 2) {
+		e := sink.entries[0]
+		assert.Equal(t, "username", e.Actor)
+		assert.Equal(t, http.MethodDelete, e.Method)
+		assert.Equal(t, "/v2/homes/12345/zones/3/overlay", e.Path)
+		assert.Equal(t, 12345, e.HomeID)
+		assert.Equal(t, 3, e.ZoneID)
+		assert.Nil(t, e.Body)
+		assert.Equal(t, http.StatusNoContent, e.StatusCode)
+		assert.NoError(t, e.Err)
+		assert.False(t, e.Time.IsZero())
+
+		e = sink.entries[1]
+		assert.Equal(t, http.MethodPut, e.Method)
+		assert.Equal(t, 0, e.HomeID)
+		assert.Equal(t, "VA01", e.DeviceSerialNo)
+		assert.Equal(t, `{"celsius":1}`, string(e.Body))
+		assert.Equal(t, http.StatusUnprocessableEntity, e.StatusCode)
+		assert.Error(t, e.Err)
+	}
+
+	// dry-run requests are audited too
+	client.DryRun = true
+	_, err = client.DeleteOverlay(&DeleteOverlayInput{HomeID: 12345, ZoneID: 3})
+	assert.NoError(t, err)
+	if assert.Len(t, sink.entries, 3) {
+		assert.True(t, sink.entries[2].DryRun)
+		assert.Equal(t, 0, sink.entries[2].StatusCode)
+		assert.Nil(t, sink.entries[2].ZoneIDs)
+	}
+
+	// requests for several zones record every zone
+	off := OverlayInput{Setting: Setting{Type: ZoneTypeHeating, Power: PowerOff}, Termination: OverlayInputTermination{Type: TerminationTypeManual}}
+	_, err = client.PostOverlays(&PostOverlaysInput{HomeID: 12345, Overlays: []ZoneOverlay{{ZoneID: 1, Overlay: off}, {ZoneID: 4, Overlay: off}}})
+	assert.NoError(t, err)
+	_, err = client.DeleteOverlays(&DeleteOverlaysInput{HomeID: 12345, ZoneIDs: []int{2, 3}})
+	assert.NoError(t, err)
+	if assert.Len(t, sink.entries, 5) {
+		assert.Equal(t, []int{1, 4}, sink.entries[3].ZoneIDs)
+		assert.Equal(t, 0, sink.entries[3].ZoneID)
+		assert.Equal(t, []int{2, 3}, sink.entries[4].ZoneIDs)
+	}
+}
+
+func TestJSONLinesAuditSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+	sink := NewJSONLinesAuditSink(buf)
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sink.Audit(AuditEntry{Time: tm, Actor: "me", Method: "PUT", Path: "/v2/homes/1/zones/2/overlay", HomeID: 1, ZoneID: 2,
+		Body: json.RawMessage(`{"a":1}`), StatusCode: 200, Latency: 1500 * time.Microsecond})
+	sink.Audit(AuditEntry{Time: tm, Actor: "me", Method: "DELETE", Path: "/v2/homes/1/invitations/x", HomeID: 1,
+		Err: errors.New("failed")})
+	sink.Audit(AuditEntry{Time: tm, Actor: "me", Method: "DELETE", Path: "/v2/homes/1/overlay?rooms=2,3", HomeID: 1,
+		ZoneIDs: []int{2, 3}, StatusCode: 204})
+
+	assert.Equal(t, `{"time":"2024-01-02T03:04:05Z","actor":"me","method":"PUT","path":"/v2/homes/1/zones/2/overlay","homeId":1,"zoneId":2,"body":{"a":1},"status":200,"latencyMs":1.5}
+{"time":"2024-01-02T03:04:05Z","actor":"me","method":"DELETE","path":"/v2/homes/1/invitations/x","homeId":1,"status":0,"latencyMs":0,"error":"failed"}
+{"time":"2024-01-02T03:04:05Z","actor":"me","method":"DELETE","path":"/v2/homes/1/overlay?rooms=2,3","homeId":1,"zoneIds":[2,3],"status":204,"latencyMs":0}
+`, buf.String())
+	assert.NoError(t, sink.Err())
+}
+
+func TestSlogAuditSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	sink := NewSlogAuditSink(logger)
+	sink.Audit(AuditEntry{Actor: "me", Method: "PUT", Path: "/v2/homes/1/zones/2/dazzle", HomeID: 1, ZoneID: 2,
+		Body: json.RawMessage(`{"enabled":true}`), StatusCode: 204, Latency: time.Second})
+	sink.Audit(AuditEntry{Actor: "me", Method: "DELETE", Path: "/v2/homes/1/zones/2/overlay", HomeID: 1, ZoneID: 2,
+		Err: errors.New("failed")})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		`level=INFO msg="tado audit" actor=me method=PUT path=/v2/homes/1/zones/2/dazzle homeId=1 zoneId=2 body="{\"enabled\":true}" status=204 latency=1s`,
+		`level=ERROR msg="tado audit" actor=me method=DELETE path=/v2/homes/1/zones/2/overlay homeId=1 zoneId=2 status=0 latency=0s error=failed`,
+	}, lines)
+}
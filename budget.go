@@ -0,0 +1,78 @@
+package tado
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitError is returned when a request is not sent because the request budget of the client is used up.
+type RateLimitError struct {
+	RequestsPerDay int
+	RetryAfter     time.Duration
+}
+
+func (rle *RateLimitError) Error() string {
+	return fmt.Sprintf("request budget of %d requests per day exceeded, retry after %s", rle.RequestsPerDay, rle.RetryAfter)
+}
+
+// rateBudget is a token bucket holding a day of requests, which is refilled continuously
+type rateBudget struct {
+	mutex     sync.Mutex
+	perDay    int
+	available float64
+	last      time.Time
+	now       func() time.Time
+}
+
+// newRateBudget returns a full budget of perDay requests
+func newRateBudget(perDay int) *rateBudget {
+	return &rateBudget{
+		perDay:    perDay,
+		available: float64(perDay),
+		last:      time.Now(),
+		now:       time.Now,
+	}
+}
+
+// take takes a request from the budget, it returns the time until a request is available if there is none
+func (rb *rateBudget) take() (time.Duration, bool) {
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+	now := rb.now()
+	perSecond := float64(rb.perDay) / (24 * time.Hour).Seconds()
+	if elapsed := now.Sub(rb.last); elapsed > 0 {
+		rb.available += elapsed.Seconds() * perSecond
+		if rb.available > float64(rb.perDay) {
+			rb.available = float64(rb.perDay)
+		}
+		rb.last = now
+	}
+	if rb.available < 1 {
+		return time.Duration((1 - rb.available) / perSecond * float64(time.Second)), false
+	}
+	rb.available--
+	return 0, true
+}
+
+// interceptor returns the Interceptor that enforces the budget
+func (rb *rateBudget) interceptor(next Doer) Doer {
+	return DoerFunc(func(call *Call) error {
+		retryAfter, ok := rb.take()
+		if !ok {
+			return &RateLimitError{RequestsPerDay: rb.perDay, RetryAfter: retryAfter.Round(time.Second)}
+		}
+		return next.Do(call)
+	})
+}
+
+// SetRequestBudget limits the client to requestsPerDay requests to Tado, 0 removes the limit.
+// The budget is refilled continuously, and requests that exceed it fail with a *RateLimitError.
+// Responses served from the cache, coalesced requests and dry-run requests do not use the budget.
+func (c *Client) SetRequestBudget(requestsPerDay int) {
+	if requestsPerDay <= 0 {
+		c.budget = nil
+		return
+	}
+	c.budget = newRateBudget(requestsPerDay)
+}
@@ -0,0 +1,201 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the number of concurrent requests used by SetOverlays when no concurrency is set
+const DefaultBulkConcurrency = 4
+
+// SetOverlaysInput is the input for SetOverlays
+type SetOverlaysInput struct {
+	HomeID   int
+	Overlays []ZoneOverlay
+
+	// Concurrency is the maximum number of concurrent requests when overlays are set one zone at a time.
+	// It defaults to DefaultBulkConcurrency.
+	Concurrency int
+
+	// Rollback restores the overlays the zones had before when setting the overlay of any zone fails.
+	// This uses one extra request to get the current zone states up front.
+	Rollback bool
+}
+
+// ZoneResult is the result of SetOverlays for a single zone
+type ZoneResult struct {
+	ZoneID int
+
+	// Err is the error returned when setting the overlay of this zone, it is nil on success.
+	Err error
+
+	// RolledBack is true when the overlay was set but the previous overlay has been restored.
+	RolledBack bool
+
+	// RollbackErr is the error returned when restoring the previous overlay failed.
+	RollbackErr error
+}
+
+// SetOverlaysOutput is the output for SetOverlays, it contains a result for every zone in the input
+type SetOverlaysOutput struct {
+	Zones []ZoneResult
+}
+
+// Err returns a *BulkError for all zones that failed, or nil when all zones succeeded.
+func (soo *SetOverlaysOutput) Err() error {
+	be := &BulkError{Zones: make(map[int]error)}
+	for _, zr := range soo.Zones {
+		if zr.Err != nil {
+			be.Zones[zr.ZoneID] = zr.Err
+		}
+	}
+	if len(be.Zones) == 0 {
+		return nil
+	}
+	return be
+}
+
+// BulkError is the error type returned when one or more zones in a bulk operation failed.
+type BulkError struct {
+	Zones map[int]error
+}
+
+func (be *BulkError) Error() string {
+	ids := make([]int, 0, len(be.Zones))
+	for id := range be.Zones {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("zone %d: %s", id, be.Zones[id]))
+	}
+	return fmt.Sprintf("bulk operation failed for %d zone(s): %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// SetOverlays sets the overlays of multiple zones in a home, for example to turn the heating off in all zones.
+// A single PostOverlays request is tried first, when Tado does not accept it the overlays are set
+// with one PutOverlay request per zone, using at most in.Concurrency concurrent requests.
+// The returned error is only set when no overlay was sent, the result of every zone is in the output.
+func (c *Client) SetOverlays(in *SetOverlaysInput) (*SetOverlaysOutput, error) {
+	err := validateZoneOverlays(in.Overlays, OverlayInput.Validate)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous GetZoneStatesOutput
+	if in.Rollback {
+		previous, err = c.GetZoneStates(&GetZoneStatesInput{HomeID: in.HomeID})
+		if err != nil {
+			return nil, fmt.Errorf("error getting zone states for rollback: %s", err)
+		}
+	}
+
+	out := &SetOverlaysOutput{
+		Zones: make([]ZoneResult, len(in.Overlays)),
+	}
+	for i, zo := range in.Overlays {
+		out.Zones[i].ZoneID = zo.ZoneID
+	}
+
+	_, err = c.PostOverlays(&PostOverlaysInput{HomeID: in.HomeID, Overlays: in.Overlays})
+	if err == nil {
+		return out, nil
+	}
+	if !bulkUnsupported(err) {
+		for i := range out.Zones {
+			out.Zones[i].Err = err
+		}
+		return out, nil
+	}
+
+	c.eachZone(out.Zones, in.Concurrency, func(i int) error {
+		_, err := c.PutOverlay(&PutOverlayInput{
+			HomeID:       in.HomeID,
+			ZoneID:       in.Overlays[i].ZoneID,
+			OverlayInput: in.Overlays[i].Overlay,
+		})
+		return err
+	})
+
+	if in.Rollback && out.Err() != nil {
+		c.rollback(in.HomeID, out.Zones, previous, in.Concurrency)
+	}
+	return out, nil
+}
+
+// eachZone calls f for every zone result with bounded concurrency and stores the returned error in the result
+func (c *Client) eachZone(results []ZoneResult, concurrency int, f func(i int) error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	wg := new(sync.WaitGroup)
+	for i := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i].Err = f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// rollback restores the previous overlay of every zone that succeeded
+func (c *Client) rollback(homeID int, results []ZoneResult, previous GetZoneStatesOutput, concurrency int) {
+	var succeeded []ZoneResult
+	for _, zr := range results {
+		if zr.Err == nil {
+			succeeded = append(succeeded, ZoneResult{ZoneID: zr.ZoneID})
+		}
+	}
+
+	c.eachZone(succeeded, concurrency, func(i int) error {
+		return c.restoreOverlay(homeID, succeeded[i].ZoneID, previous[succeeded[i].ZoneID])
+	})
+
+	for _, s := range succeeded {
+		for i := range results {
+			if results[i].ZoneID != s.ZoneID {
+				continue
+			}
+			results[i].RolledBack = s.Err == nil
+			results[i].RollbackErr = s.Err
+		}
+	}
+}
+
+// restoreOverlay sets the overlay from a previous zone state, or deletes the overlay if the zone had none
+func (c *Client) restoreOverlay(homeID, zoneID int, zs ZoneState) error {
+	if zs.OverlayType == "" {
+		_, err := c.DeleteOverlay(&DeleteOverlayInput{HomeID: homeID, ZoneID: zoneID})
+		return err
+	}
+	_, err := c.PutOverlay(&PutOverlayInput{
+		HomeID:       homeID,
+		ZoneID:       zoneID,
+		OverlayInput: zs.Overlay.Input(),
+	})
+	return err
+}
+
+// bulkUnsupported returns true if err means the bulk overlay endpoint is not available and should be retried per zone.
+// Other errors, such as a rejected payload, are returned to the caller.
+func bulkUnsupported(err error) bool {
+	he, ok := err.(*HTTPError)
+	if !ok {
+		return false
+	}
+	switch he.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package tado
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func heatingOff(zoneIDs ...int) []ZoneOverlay {
+	var zo []ZoneOverlay
+	for _, id := range zoneIDs {
+		in, _ := NewOverlay(12345, id).Off().Build()
+		zo = append(zo, ZoneOverlay{ZoneID: id, Overlay: in.OverlayInput})
+	}
+	return zo
+}
+
+func TestClient_SetOverlays_Bulk(t *testing.T) {
+
+	calls := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/v2/homes/12345/overlay", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"overlays":[{"room":1,"overlay":{"setting":{"type":"HEATING","power":"OFF","temperature":{}},"termination":{"type":"MANUAL"}}},{"room":2,"overlay":{"setting":{"type":"HEATING","power":"OFF","temperature":{}},"termination":{"type":"MANUAL"}}}]}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	out, err := client.SetOverlays(&SetOverlaysInput{
+		HomeID:   12345,
+		Overlays: heatingOff(1, 2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []ZoneResult{{ZoneID: 1}, {ZoneID: 2}}, out.Zones)
+	assert.NoError(t, out.Err())
+}
+
+func TestClient_SetOverlays_BulkRejected(t *testing.T) {
+
+	calls := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(w, `{"errors": [{"code": "invalid"}]}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	out, err := client.SetOverlays(&SetOverlaysInput{
+		HomeID:   12345,
+		Overlays: heatingOff(1, 2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a rejected payload is not sent again per zone
+	assert.Equal(t, 1, calls)
+	for _, zr := range out.Zones {
+		if assert.IsType(t, &HTTPError{}, zr.Err) {
+			assert.Equal(t, http.StatusUnprocessableEntity, zr.Err.(*HTTPError).StatusCode)
+		}
+	}
+}
+
+func TestBulkUnsupported(t *testing.T) {
+	for status, unsupported := range map[int]bool{
+		http.StatusNotFound:            true,
+		http.StatusMethodNotAllowed:    true,
+		http.StatusNotImplemented:      true,
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusUnprocessableEntity: false,
+		http.StatusTooManyRequests:     false,
+		http.StatusInternalServerError: false,
+	} {
+		assert.Equal(t, unsupported, bulkUnsupported(&HTTPError{StatusCode: status}), "status %d", status)
+	}
+	assert.False(t, bulkUnsupported(errors.New("HTTP error: EOF")))
+}
+
+func TestClient_SetOverlays_FallbackAndRollback(t *testing.T) {
+
+	var mu sync.Mutex
+	var requests []string
+	f := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/v2/homes/12345/zoneStates":
+			_, _ = fmt.Fprint(w, `{"zoneStates": {
+				"1": {"overlayType": "MANUAL", "overlay": {"type": "MANUAL", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 23}}, "termination": {"type": "TIMER", "durationInSeconds": 3600, "remainingTimeInSeconds": 1200}}},
+				"2": {},
+				"3": {}
+			}}`)
+		case r.URL.Path == "/v2/homes/12345/overlay":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/v2/homes/12345/zones/3/overlay":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(w, "zone 3 is broken")
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/homes/12345/zones/1/overlay":
+			b, _ := ioutil.ReadAll(r.Body)
+			if string(b) != `{"setting":{"type":"HEATING","power":"OFF","temperature":{}},"termination":{"type":"MANUAL"}}`+"\n" {
+				// the rollback restores the remaining time of the previous timer
+				assert.Equal(t, `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":23}},"termination":{"type":"TIMER","durationInSeconds":1200}}`+"\n", string(b))
+			}
+			_, _ = fmt.Fprint(w, `{}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/homes/12345/zones/2/overlay":
+			_, _ = fmt.Fprint(w, `{}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/homes/12345/zones/2/overlay":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	out, err := client.SetOverlays(&SetOverlaysInput{
+		HomeID:      12345,
+		Overlays:    heatingOff(1, 2, 3),
+		Concurrency: 2,
+		Rollback:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, out.Zones, 3) {
+		assert.Equal(t, ZoneResult{ZoneID: 1, RolledBack: true}, out.Zones[0])
+		assert.Equal(t, ZoneResult{ZoneID: 2, RolledBack: true}, out.Zones[1])
+		assert.Equal(t, 3, out.Zones[2].ZoneID)
+		assert.False(t, out.Zones[2].RolledBack)
+		if assert.Error(t, out.Zones[2].Err) {
+			assert.Equal(t, "error: HTTP status 500: zone 3 is broken", out.Zones[2].Err.Error())
+		}
+	}
+	if assert.Error(t, out.Err()) {
+		assert.Equal(t, "bulk operation failed for 1 zone(s): zone 3: error: HTTP status 500: zone 3 is broken", out.Err().Error())
+	}
+
+	sort.Strings(requests)
+	assert.Equal(t, []string{
+		"DELETE /v2/homes/12345/zones/2/overlay",
+		"GET /v2/homes/12345/zoneStates",
+		"POST /v2/homes/12345/overlay",
+		"PUT /v2/homes/12345/zones/1/overlay",
+		"PUT /v2/homes/12345/zones/1/overlay",
+		"PUT /v2/homes/12345/zones/2/overlay",
+		"PUT /v2/homes/12345/zones/3/overlay",
+	}, requests)
+}
+
+func TestClient_SetOverlays_Invalid(t *testing.T) {
+	client := NewClient("", "")
+
+	_, err := client.SetOverlays(&SetOverlaysInput{
+		HomeID: 12345,
+		Overlays: []ZoneOverlay{
+			{ZoneID: 7, Overlay: OverlayInput{Setting: Setting{Type: ZoneTypeHeating, Power: PowerOn}}},
+		},
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, "zone 7: invalid overlay: HEATING with power ON requires a temperature", err.Error())
+	}
+
+	_, err = client.PostOverlays(&PostOverlaysInput{
+		HomeID: 12345,
+		Overlays: []ZoneOverlay{
+			{ZoneID: 8, Overlay: OverlayInput{Setting: Setting{Type: ZoneTypeHeating, Power: PowerOff, Temperature: Temperature{Celsius: 18}}}},
+		},
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, "zone 8: invalid overlay: temperature set while power is OFF", err.Error())
+	}
+}
+
+func TestClient_DeleteOverlays(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/overlay", r.URL.Path)
+		assert.Equal(t, "rooms=1,2,5", r.URL.RawQuery)
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	r, err := client.DeleteOverlays(&DeleteOverlaysInput{HomeID: 12345, ZoneIDs: []int{1, 2, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	assert.NotNil(t, r)
+}
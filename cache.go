@@ -0,0 +1,320 @@
+package tado
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTLs are the TTLs used by NewCache, keyed by the input type without Input suffix.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"GetMe":               time.Hour,
+	"GetHome":             time.Hour,
+	"GetZones":            time.Hour,
+	"GetDevices":          time.Hour,
+	"GetUsers":            10 * time.Minute,
+	"GetZoneCapabilities": 24 * time.Hour,
+}
+
+// CacheEntry is a cached response.
+type CacheEntry struct {
+	Key     string    `json:"key"`
+	Body    []byte    `json:"body"`
+	ETag    string    `json:"etag,omitempty"`
+	Expires time.Time `json:"expires"`
+}
+
+// CacheBackend stores cache entries, it must be safe for concurrent use.
+// A backend must not be shared between clients of different accounts.
+type CacheBackend interface {
+	Get(key string) (CacheEntry, bool)
+	Set(e CacheEntry)
+	Delete(key string)
+	// DeletePrefix deletes all entries of which the key starts with prefix
+	DeletePrefix(prefix string)
+}
+
+// Cache caches GET responses, set it on Client.Cache to enable caching.
+// Responses are cached per input type for the TTL in TTLs, input types without TTL are not cached.
+// Expired responses with an ETag are revalidated using If-None-Match.
+// Successful mutations evict the cached responses they affect: a change to a zone evicts the responses of that
+// zone and the zone list and states of the home, other changes to a home evict everything of that home and the
+// user data, and changes to a device evict the responses of that device.
+type Cache struct {
+	Backend CacheBackend
+	TTLs    map[string]time.Duration
+
+	now func() time.Time
+}
+
+// NewCache returns a cache with the DefaultCacheTTLs.
+func NewCache(backend CacheBackend) *Cache {
+	ttls := make(map[string]time.Duration, len(DefaultCacheTTLs))
+	for k, v := range DefaultCacheTTLs {
+		ttls[k] = v
+	}
+	return &Cache{
+		Backend: backend,
+		TTLs:    ttls,
+		now:     time.Now,
+	}
+}
+
+func (c *Cache) timeNow() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
+
+// cacheKey returns the key of a call
+func cacheKey(call *Call) string {
+	return call.Method + " " + call.Path
+}
+
+// interceptor returns the Interceptor that serves calls from the cache
+func (c *Cache) interceptor(next Doer) Doer {
+	return DoerFunc(func(call *Call) error {
+		if call.Method != http.MethodGet {
+			err := next.Do(call)
+			if err == nil && call.StatusCode() >= 200 && call.StatusCode() < 300 {
+				c.invalidate(call)
+			}
+			return err
+		}
+
+		ttl := c.TTLs[call.Name()]
+		if ttl <= 0 {
+			return next.Do(call)
+		}
+
+		key := cacheKey(call)
+		e, ok := c.Backend.Get(key)
+		if ok && c.timeNow().Before(e.Expires) {
+			call.Cached = true
+			call.ResponseBody = e.Body
+			call.Response = &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Content-Type": {"application/json"}},
+				Body:          io.NopCloser(bytes.NewReader(e.Body)),
+				ContentLength: int64(len(e.Body)),
+				Request:       call.Request,
+			}
+			return nil
+		}
+		if ok && e.ETag != "" {
+			call.Request.Header.Set("If-None-Match", e.ETag)
+		}
+
+		err := next.Do(call)
+		if err != nil {
+			return err
+		}
+
+		switch call.StatusCode() {
+		case http.StatusNotModified:
+			// still valid, use the cached body
+			call.Cached = true
+			call.ResponseBody = e.Body
+			e.Expires = c.timeNow().Add(ttl)
+			c.Backend.Set(e)
+		case http.StatusOK:
+			c.Backend.Set(CacheEntry{
+				Key:     key,
+				Body:    call.ResponseBody,
+				ETag:    call.Response.Header.Get("ETag"),
+				Expires: c.timeNow().Add(ttl),
+			})
+		}
+		return nil
+	})
+}
+
+// invalidate evicts the cached responses affected by a mutating call
+func (c *Cache) invalidate(call *Call) {
+	homeID, zoneID, serialNo := call.HomeID(), call.ZoneID(), call.DeviceSerialNo()
+	switch {
+	case homeID != 0 && zoneID != 0:
+		home := "GET /v2/homes/" + strconv.Itoa(homeID)
+		c.Backend.DeletePrefix(home + "/zones/" + strconv.Itoa(zoneID) + "/")
+		c.Backend.Delete(home + "/zones")
+		c.Backend.Delete(home + "/zoneStates")
+	case homeID != 0:
+		c.Backend.DeletePrefix("GET /v2/homes/" + strconv.Itoa(homeID) + "/")
+		c.Backend.Delete("GET /v2/homes/" + strconv.Itoa(homeID))
+		c.Backend.Delete("GET /v2/me")
+	case serialNo != "":
+		c.Backend.DeletePrefix("GET /v2/devices/" + serialNo + "/")
+	}
+}
+
+// LRUCache is an in-memory CacheBackend that holds a maximum number of entries.
+type LRUCache struct {
+	size    int
+	mutex   sync.Mutex
+	list    *list.List
+	entries map[string]*list.Element
+}
+
+// NewLRUCache returns an in-memory cache backend holding at most size entries.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		list:    list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry for key
+func (lc *LRUCache) Get(key string) (CacheEntry, bool) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	el, ok := lc.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	lc.list.MoveToFront(el)
+	return el.Value.(CacheEntry), true
+}
+
+// Set stores e, the least recently used entry is removed when the cache is full
+func (lc *LRUCache) Set(e CacheEntry) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	if el, ok := lc.entries[e.Key]; ok {
+		el.Value = e
+		lc.list.MoveToFront(el)
+		return
+	}
+	lc.entries[e.Key] = lc.list.PushFront(e)
+	for lc.list.Len() > lc.size {
+		el := lc.list.Back()
+		lc.list.Remove(el)
+		delete(lc.entries, el.Value.(CacheEntry).Key)
+	}
+}
+
+// Delete removes the entry for key
+func (lc *LRUCache) Delete(key string) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	if el, ok := lc.entries[key]; ok {
+		lc.list.Remove(el)
+		delete(lc.entries, key)
+	}
+}
+
+// DeletePrefix removes all entries of which the key starts with prefix
+func (lc *LRUCache) DeletePrefix(prefix string) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	for key, el := range lc.entries {
+		if strings.HasPrefix(key, prefix) {
+			lc.list.Remove(el)
+			delete(lc.entries, key)
+		}
+	}
+}
+
+// Len returns the number of entries in the cache
+func (lc *LRUCache) Len() int {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	return lc.list.Len()
+}
+
+// FileCache is a CacheBackend that stores every entry as a JSON file in a directory.
+// Errors reading or writing files are treated as cache misses.
+type FileCache struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileCache returns a cache backend storing entries in dir, which is created if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// fileName returns the file name for key
+func (fc *FileCache) fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(fc.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the entry for key
+func (fc *FileCache) Get(key string) (CacheEntry, bool) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	e, err := readCacheFile(fc.fileName(key))
+	if err != nil || e.Key != key {
+		return CacheEntry{}, false
+	}
+	return e, true
+}
+
+// Set stores e
+func (fc *FileCache) Set(e CacheEntry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	// write to a temporary file first so a partial file is never read
+	name := fc.fileName(e.Key)
+	err = os.WriteFile(name+".tmp", b, 0o600)
+	if err != nil {
+		return
+	}
+	_ = os.Rename(name+".tmp", name)
+}
+
+// Delete removes the entry for key
+func (fc *FileCache) Delete(key string) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	_ = os.Remove(fc.fileName(key))
+}
+
+// DeletePrefix removes all entries of which the key starts with prefix
+func (fc *FileCache) DeletePrefix(prefix string) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	names, err := filepath.Glob(filepath.Join(fc.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		e, err := readCacheFile(name)
+		if err != nil || strings.HasPrefix(e.Key, prefix) {
+			_ = os.Remove(name)
+		}
+	}
+}
+
+func readCacheFile(name string) (CacheEntry, error) {
+	var e CacheEntry
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return e, err
+	}
+	err = json.Unmarshal(b, &e)
+	return e, err
+}
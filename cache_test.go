@@ -0,0 +1,153 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Cache(t *testing.T) {
+	var requests []string
+	f := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("If-None-Match"))
+		switch {
+		case r.Method != http.MethodGet:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/v2/homes/1":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = fmt.Fprint(w, `{"id": 1, "name": "Home"}`)
+		case r.URL.Path == "/v2/homes/1/zones/2/state":
+			_, _ = fmt.Fprint(w, `{"tadoMode": "HOME"}`)
+		default:
+			_, _ = fmt.Fprint(w, `{}`)
+		}
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	now := time.Now()
+	client.Cache = NewCache(NewLRUCache(10))
+	client.Cache.now = func() time.Time { return now }
+	client.Cache.TTLs["GetZoneState"] = 30 * time.Second
+
+	var cached []bool
+	client.Interceptors = []Interceptor{func(next Doer) Doer {
+		return DoerFunc(func(c *Call) error {
+			err := next.Do(c)
+			cached = append(cached, c.Cached)
+			return err
+		})
+	}}
+
+	// second call is served from the cache
+	for i := 0; i < 2; i++ {
+		home, err := client.GetHome(&GetHomeInput{HomeID: 1})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Home", home.Name)
+		}
+	}
+	assert.Equal(t, []string{"GET /v2/homes/1 "}, requests)
+	assert.Equal(t, []bool{false, true}, cached)
+
+	// expired, revalidate with ETag
+	now = now.Add(2 * time.Hour)
+	home, err := client.GetHome(&GetHomeInput{HomeID: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Home", home.Name)
+	}
+	assert.Equal(t, `GET /v2/homes/1 "v1"`, requests[1])
+
+	// input types without TTL are not cached
+	_, err = client.GetWeather(&GetWeatherInput{HomeID: 1})
+	assert.NoError(t, err)
+	_, err = client.GetWeather(&GetWeatherInput{HomeID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 4)
+
+	// PutOverlay evicts the zone state, but not the home
+	requests = nil
+	_, err = client.GetZoneState(&GetZoneStateInput{HomeID: 1, ZoneID: 2})
+	assert.NoError(t, err)
+	_, err = client.GetZoneState(&GetZoneStateInput{HomeID: 1, ZoneID: 2})
+	assert.NoError(t, err)
+	_, err = client.DeleteOverlay(&DeleteOverlayInput{HomeID: 1, ZoneID: 2})
+	assert.NoError(t, err)
+	_, err = client.GetZoneState(&GetZoneStateInput{HomeID: 1, ZoneID: 2})
+	assert.NoError(t, err)
+	_, err = client.GetHome(&GetHomeInput{HomeID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /v2/homes/1/zones/2/state ",
+		"DELETE /v2/homes/1/zones/2/overlay ",
+		"GET /v2/homes/1/zones/2/state ",
+	}, requests)
+
+	// a change to the home evicts everything of that home
+	requests = nil
+	_, err = client.PutAwayRadius(&PutAwayRadiusInput{HomeID: 1, AwayRadius: AwayRadius{AwayRadiusInMeters: 500}})
+	assert.NoError(t, err)
+	_, err = client.GetHome(&GetHomeInput{HomeID: 1})
+	assert.NoError(t, err)
+	_, err = client.GetZoneState(&GetZoneStateInput{HomeID: 1, ZoneID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"PUT /v2/homes/1/awayRadiusInMeters ",
+		"GET /v2/homes/1 ",
+		"GET /v2/homes/1/zones/2/state ",
+	}, requests)
+}
+
+func testCacheBackend(t *testing.T, b CacheBackend) {
+	_, ok := b.Get("GET /v2/me")
+	assert.False(t, ok)
+
+	b.Set(CacheEntry{Key: "GET /v2/me", Body: []byte(`{}`), ETag: "x"})
+	b.Set(CacheEntry{Key: "GET /v2/homes/1/zones", Body: []byte(`[]`)})
+	b.Set(CacheEntry{Key: "GET /v2/homes/1/zones/2/state", Body: []byte(`{}`)})
+
+	e, ok := b.Get("GET /v2/me")
+	if assert.True(t, ok) {
+		assert.Equal(t, `{}`, string(e.Body))
+		assert.Equal(t, "x", e.ETag)
+	}
+
+	b.DeletePrefix("GET /v2/homes/1/zones/")
+	_, ok = b.Get("GET /v2/homes/1/zones/2/state")
+	assert.False(t, ok)
+	_, ok = b.Get("GET /v2/homes/1/zones")
+	assert.True(t, ok)
+
+	b.Delete("GET /v2/homes/1/zones")
+	_, ok = b.Get("GET /v2/homes/1/zones")
+	assert.False(t, ok)
+}
+
+func TestLRUCache(t *testing.T) {
+	testCacheBackend(t, NewLRUCache(10))
+
+	lc := NewLRUCache(2)
+	lc.Set(CacheEntry{Key: "a"})
+	lc.Set(CacheEntry{Key: "b"})
+	lc.Get("a")
+	lc.Set(CacheEntry{Key: "c"})
+	assert.Equal(t, 2, lc.Len())
+	_, ok := lc.Get("b")
+	assert.False(t, ok, "least recently used entry must be removed")
+	_, ok = lc.Get("a")
+	assert.True(t, ok)
+}
+
+func TestFileCache(t *testing.T) {
+	fc, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCacheBackend(t, fc)
+}
@@ -0,0 +1,73 @@
+package tado
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flight is a GET request in flight, shared by all identical calls
+type flight struct {
+	done     chan struct{}
+	response *http.Response
+	body     []byte
+	cached   bool
+	err      error
+}
+
+// coalescer shares one round trip between identical GET calls that are in flight at the same time
+type coalescer struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+}
+
+// interceptor returns the Interceptor that coalesces calls
+func (co *coalescer) interceptor(next Doer) Doer {
+	return DoerFunc(func(call *Call) error {
+		if call.Method != http.MethodGet {
+			return next.Do(call)
+		}
+
+		key := call.Method + " " + call.Path
+		co.mutex.Lock()
+		if f, ok := co.flights[key]; ok {
+			// an identical call is in flight, wait for its result
+			co.mutex.Unlock()
+			<-f.done
+			f.copyTo(call)
+			return f.err
+		}
+		f := &flight{done: make(chan struct{})}
+		if co.flights == nil {
+			co.flights = make(map[string]*flight)
+		}
+		co.flights[key] = f
+		co.mutex.Unlock()
+
+		f.err = next.Do(call)
+		f.response, f.body, f.cached = call.Response, call.ResponseBody, call.Cached
+
+		co.mutex.Lock()
+		delete(co.flights, key)
+		co.mutex.Unlock()
+		close(f.done)
+
+		return f.err
+	})
+}
+
+// copyTo sets the result of the flight on call, every call gets its own copy of the response body
+func (f *flight) copyTo(call *Call) {
+	call.Cached = f.cached
+	if f.body != nil {
+		call.ResponseBody = append([]byte(nil), f.body...)
+	}
+	if f.response != nil {
+		resp := *f.response
+		resp.Header = f.response.Header.Clone()
+		resp.Body = io.NopCloser(bytes.NewReader(call.ResponseBody))
+		resp.Request = call.Request
+		call.Response = &resp
+	}
+}
@@ -0,0 +1,113 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countCalls adds an outermost interceptor to c that counts the calls that started
+func countCalls(c *Client) *int32 {
+	n := new(int32)
+	c.Interceptors = append([]Interceptor{func(next Doer) Doer {
+		return DoerFunc(func(call *Call) error {
+			atomic.AddInt32(n, 1)
+			return next.Do(call)
+		})
+	}}, c.Interceptors...)
+	return n
+}
+
+// waitForCallers waits until n calls started and gives them time to join the same flight
+func waitForCallers(t *testing.T, entered *int32, n int32) {
+	for deadline := time.Now().Add(5 * time.Second); atomic.LoadInt32(entered) < n; {
+		if time.Now().After(deadline) {
+			t.Fatal("callers did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestClient_CoalesceRequests(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	f := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		_, _ = fmt.Fprint(w, `{"tadoMode": "HOME", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 20}}}`)
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+	client.CoalesceRequests = true
+	entered := countCalls(client)
+
+	const callers = 10
+	outputs := make([]*GetZoneStateOutput, callers)
+	errs := make([]error, callers)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i], errs[i] = client.GetZoneState(&GetZoneStateInput{HomeID: 1, ZoneID: 2})
+		}(i)
+	}
+
+	waitForCallers(t, entered, callers)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for i := 0; i < callers; i++ {
+		if assert.NoError(t, errs[i]) {
+			assert.Equal(t, 20.0, outputs[i].Setting.Temperature.Celsius)
+		}
+	}
+	// every caller has its own copy
+	outputs[0].Setting.Temperature.Celsius = 99
+	assert.Equal(t, 20.0, outputs[1].Setting.Temperature.Celsius)
+
+	// calls after the flight finished make a new request
+	_, err := client.GetZoneState(&GetZoneStateInput{HomeID: 1, ZoneID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Empty(t, client.coalescer.flights)
+}
+
+func TestClient_CoalesceRequests_Errors(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	f := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprint(w, `down`)
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+	client.CoalesceRequests = true
+	entered := countCalls(client)
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := client.GetZones(&GetZonesInput{HomeID: 1})
+			errs <- err
+		}()
+	}
+	waitForCallers(t, entered, 2)
+	close(release)
+	for i := 0; i < 2; i++ {
+		err := <-errs
+		if assert.Error(t, err) {
+			assert.Equal(t, "error: HTTP status 500: down", err.Error())
+		}
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
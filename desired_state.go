@@ -0,0 +1,371 @@
+package tado
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DesiredState describes the wanted configuration of one or more homes, use Reconcile to apply it.
+// Settings that are left empty are not managed and keep their current value.
+type DesiredState struct {
+	Homes []DesiredHome `json:"homes" yaml:"homes"`
+}
+
+// DesiredHome is the wanted configuration of a single home
+type DesiredHome struct {
+	ID int `json:"id" yaml:"id"`
+	// TemperatureUnit is the unit of all temperatures of this home, it defaults to Celsius.
+	TemperatureUnit    TemperatureUnit `json:"temperatureUnit,omitempty" yaml:"temperatureUnit,omitempty"`
+	AwayRadiusInMeters *float64        `json:"awayRadiusInMeters,omitempty" yaml:"awayRadiusInMeters,omitempty"`
+	Zones              []DesiredZone   `json:"zones,omitempty" yaml:"zones,omitempty"`
+}
+
+// DesiredZone is the wanted configuration of a single zone.
+// A zone is found by ID when it is set, Name is then the wanted name of the zone.
+// Without ID the zone is found by Name, names are compared case-insensitive.
+type DesiredZone struct {
+	ID   int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Schedule replaces the blocks of the day types it contains.
+	Schedule *DesiredSchedule `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	// AwayTemperature is the temperature used when everyone is away.
+	AwayTemperature *float64 `json:"awayTemperature,omitempty" yaml:"awayTemperature,omitempty"`
+	// OpenWindowTimeoutInSeconds is the time the heating is off after an open window is detected, 0 disables
+	// open window detection.
+	OpenWindowTimeoutInSeconds *int `json:"openWindowTimeoutInSeconds,omitempty" yaml:"openWindowTimeoutInSeconds,omitempty"`
+	// EarlyStart enables or disables early start.
+	EarlyStart *bool `json:"earlyStart,omitempty" yaml:"earlyStart,omitempty"`
+	// TemperatureOffset is the offset of all devices in the zone that measure the temperature, it is a difference
+	// in the TemperatureUnit of the desired home.
+	TemperatureOffset *float64 `json:"temperatureOffset,omitempty" yaml:"temperatureOffset,omitempty"`
+}
+
+// DesiredSchedule is the wanted schedule of a zone
+type DesiredSchedule struct {
+	// Timetable is the type of timetable to use, it defaults to the current timetable of the zone.
+	Timetable TimetableType `json:"timetable,omitempty" yaml:"timetable,omitempty"`
+	// Days contains the blocks per day type, for example MONDAY_TO_FRIDAY for a THREE_DAY timetable.
+	Days map[string][]DesiredBlock `json:"days" yaml:"days"`
+}
+
+// DesiredBlock is a block of a day in a schedule, it lasts until the start of the next block or the end of the day.
+type DesiredBlock struct {
+	// Start is the start time of the block, for example 07:30. The first block of a day must start at 00:00.
+	Start string `json:"start" yaml:"start"`
+	// Temperature is the temperature during the block, the heating is off when it is not set.
+	Temperature *float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+}
+
+// timetableIDs contains the timetable ID for every timetable type
+var timetableIDs = map[TimetableType]int{
+	TimetableTypeOneDay:   0,
+	TimetableTypeThreeDay: 1,
+	TimetableTypeSevenDay: 2,
+}
+
+// timetableDayTypes contains the day types used in every timetable type
+var timetableDayTypes = map[TimetableType][]string{
+	TimetableTypeOneDay:   {"MONDAY_TO_SUNDAY"},
+	TimetableTypeThreeDay: {"MONDAY_TO_FRIDAY", "SATURDAY", "SUNDAY"},
+	TimetableTypeSevenDay: {"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"},
+}
+
+// ReadDesiredState reads a desired state in YAML or JSON format.
+func ReadDesiredState(r io.Reader) (*DesiredState, error) {
+	br := bufio.NewReader(r)
+	ds := new(DesiredState)
+	var err error
+	if isJSON(br) {
+		dec := json.NewDecoder(br)
+		dec.DisallowUnknownFields()
+		err = dec.Decode(ds)
+	} else {
+		dec := yaml.NewDecoder(br)
+		dec.SetStrict(true)
+		err = dec.Decode(ds)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding desired state: %s", err)
+	}
+	return ds, nil
+}
+
+// isJSON returns true if the first non whitespace character is the start of a JSON object
+func isJSON(br *bufio.Reader) bool {
+	for i := 1; ; i++ {
+		b, err := br.Peek(i)
+		if err != nil {
+			return false
+		}
+		switch b[i-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		}
+		return false
+	}
+}
+
+// PlanReconcile returns a plan for every home in desired with the changes needed to reach the desired state.
+// Nothing is changed, use ApplyPlan to apply the plans or Reconcile to plan and apply in one go.
+func (c *Client) PlanReconcile(ctx context.Context, desired *DesiredState) ([]*Plan, error) {
+	plans := make([]*Plan, 0, len(desired.Homes))
+	for _, dh := range desired.Homes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		current, err := c.TakeSnapshot(dh.ID)
+		if err != nil {
+			return nil, fmt.Errorf("home %d: %s", dh.ID, err)
+		}
+		wanted, skipped, err := dh.apply(current)
+		if err != nil {
+			return nil, fmt.Errorf("home %d: %s", dh.ID, err)
+		}
+		p := diffSnapshots(current, wanted)
+		p.Skipped = append(p.Skipped, skipped...)
+		plans = append(plans, p)
+	}
+	return plans, nil
+}
+
+// Reconcile brings every home in desired to the desired state, only settings that differ are changed.
+// It returns the applied plans, when applying a plan fails the plans of the remaining homes are not applied.
+func (c *Client) Reconcile(ctx context.Context, desired *DesiredState) ([]*Plan, error) {
+	plans, err := c.PlanReconcile(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range plans {
+		_, err := c.ApplyPlan(ctx, p)
+		if err != nil {
+			return plans[:i+1], fmt.Errorf("home %d: %s", p.HomeID, err)
+		}
+	}
+	return plans, nil
+}
+
+// apply returns a copy of the current snapshot changed to the desired state of the home,
+// and a description of the desired zones that were not found
+func (dh *DesiredHome) apply(current *Snapshot) (*Snapshot, []string, error) {
+	unit := dh.TemperatureUnit
+	if unit == "" {
+		unit = TemperatureUnitCelsius
+	}
+
+	// copy the snapshot using JSON so the current snapshot is not changed
+	wanted := new(Snapshot)
+	b, err := json.Marshal(current)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = json.Unmarshal(b, wanted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dh.AwayRadiusInMeters != nil {
+		wanted.Home.AwayRadiusInMeters = *dh.AwayRadiusInMeters
+	}
+
+	devices := make(map[string]*DeviceSnapshot, len(wanted.Devices))
+	for i := range wanted.Devices {
+		devices[wanted.Devices[i].Device.SerialNo] = &wanted.Devices[i]
+	}
+
+	var skipped []string
+	for _, dz := range dh.Zones {
+		zs, err := findZoneSnapshot(wanted.Zones, dz)
+		if err != nil {
+			return nil, nil, err
+		}
+		if zs == nil {
+			skipped = append(skipped, fmt.Sprintf("zone %d %q, it does not exist", dz.ID, dz.Name))
+			continue
+		}
+		err = dz.apply(zs, devices, unit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("zone %d %q: %s", zs.Zone.ID, zs.Zone.Name, err)
+		}
+	}
+	return wanted, skipped, nil
+}
+
+// findZoneSnapshot returns the zone for dz, or nil if it does not exist
+func findZoneSnapshot(zones []ZoneSnapshot, dz DesiredZone) (*ZoneSnapshot, error) {
+	var found []*ZoneSnapshot
+	for i := range zones {
+		z := &zones[i]
+		if (dz.ID != 0 && z.Zone.ID == dz.ID) || (dz.ID == 0 && strings.EqualFold(strings.TrimSpace(z.Zone.Name), strings.TrimSpace(dz.Name))) {
+			found = append(found, z)
+		}
+	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("zone name %q is ambiguous, set the zone ID", dz.Name)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	return found[0], nil
+}
+
+// apply changes the zone snapshot to the desired state of the zone
+func (dz *DesiredZone) apply(zs *ZoneSnapshot, devices map[string]*DeviceSnapshot, unit TemperatureUnit) error {
+	if dz.ID != 0 && dz.Name != "" {
+		zs.Zone.Name = dz.Name
+	}
+	if dz.Schedule != nil {
+		err := dz.Schedule.apply(zs, unit)
+		if err != nil {
+			return err
+		}
+	}
+	if dz.AwayTemperature != nil {
+		ac := AwayConfiguration{
+			Type:       zs.Zone.Type,
+			AutoAdjust: false,
+			Setting: &Setting{
+				Type:        zs.Zone.Type,
+				Power:       PowerOn,
+				Temperature: NewTemperature(*dz.AwayTemperature, unit),
+			},
+		}
+		if zs.AwayConfiguration != nil {
+			ac.ComfortLevel = zs.AwayConfiguration.ComfortLevel
+			if zs.AwayConfiguration.Setting != nil && NewTemperature(*dz.AwayTemperature, unit).Equal(zs.AwayConfiguration.Setting.Temperature) {
+				// keep the temperature exactly as Tado returned it, so it is not changed by rounding
+				ac.Setting.Temperature = zs.AwayConfiguration.Setting.Temperature
+			}
+		}
+		zs.AwayConfiguration = &ac
+	}
+	if dz.OpenWindowTimeoutInSeconds != nil {
+		zs.Zone.OpenWindowDetection.Enabled = *dz.OpenWindowTimeoutInSeconds > 0
+		zs.Zone.OpenWindowDetection.TimeoutInSeconds = *dz.OpenWindowTimeoutInSeconds
+	}
+	if dz.EarlyStart != nil {
+		zs.EarlyStart = &EarlyStart{Enabled: *dz.EarlyStart}
+	}
+	if dz.TemperatureOffset != nil {
+		offset := NewTemperatureOffset(*dz.TemperatureOffset, unit)
+		for _, d := range zs.Zone.Devices {
+			ds, ok := devices[d.SerialNo]
+			if !ok || !ds.Device.HasCapability(CapabilityInsideTemperatureMeasurement) {
+				continue
+			}
+			if ds.TemperatureOffset == nil || !ds.TemperatureOffset.OffsetEqual(offset) {
+				ds.TemperatureOffset = &offset
+			}
+		}
+	}
+	return nil
+}
+
+// apply changes the timetable and blocks of the zone snapshot to the desired schedule
+func (ds *DesiredSchedule) apply(zs *ZoneSnapshot, unit TemperatureUnit) error {
+	tt := ds.Timetable
+	if tt == "" {
+		tt = zs.ActiveTimetable.Type
+	}
+	id, ok := timetableIDs[tt]
+	if !ok {
+		return fmt.Errorf("unknown timetable %q", tt)
+	}
+	if id != zs.ActiveTimetable.ID {
+		zs.ActiveTimetable = Timetable{ID: id, Type: tt}
+		zs.Blocks = nil
+	}
+
+	dayTypes := make([]string, 0, len(ds.Days))
+	for dt := range ds.Days {
+		dayTypes = append(dayTypes, dt)
+	}
+	sort.Strings(dayTypes)
+
+	for _, dt := range dayTypes {
+		if !containsString(timetableDayTypes[tt], dt) {
+			return fmt.Errorf("day type %s is not part of timetable %s", dt, tt)
+		}
+		blocks, err := desiredBlocks(dt, ds.Days[dt], zs, unit)
+		if err != nil {
+			return err
+		}
+		// replace the blocks of this day type
+		kept := make([]ScheduleBlock, 0, len(zs.Blocks))
+		for _, b := range zs.Blocks {
+			if b.DayType != dt {
+				kept = append(kept, b)
+			}
+		}
+		zs.Blocks = append(kept, blocks...)
+	}
+	return nil
+}
+
+// desiredBlocks converts the desired blocks of a day type into schedule blocks
+func desiredBlocks(dayType string, dbs []DesiredBlock, zs *ZoneSnapshot, unit TemperatureUnit) ([]ScheduleBlock, error) {
+	// parse the start times, they are sent to Tado as HH:MM
+	starts := make([]time.Time, len(dbs))
+	for i, db := range dbs {
+		t, err := time.Parse("15:04", db.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start %q in %s, use HH:MM", db.Start, dayType)
+		}
+		starts[i] = t
+	}
+	if len(dbs) == 0 || starts[0].Hour() != 0 || starts[0].Minute() != 0 {
+		return nil, fmt.Errorf("the first block of %s must start at 00:00", dayType)
+	}
+
+	// current blocks are used to keep settings Tado returned that are equal to the desired settings
+	current := blocksByDayType(zs.Blocks)[dayType]
+
+	blocks := make([]ScheduleBlock, 0, len(dbs))
+	for i, db := range dbs {
+		if i > 0 && !starts[i].After(starts[i-1]) {
+			return nil, fmt.Errorf("blocks of %s must be in order, %s starts before %s", dayType, db.Start, dbs[i-1].Start)
+		}
+		end := "00:00"
+		if i < len(dbs)-1 {
+			end = starts[i+1].Format("15:04")
+		}
+		s := Setting{
+			Type:  zs.Zone.Type,
+			Power: PowerOff,
+		}
+		if db.Temperature != nil {
+			s.Power = PowerOn
+			s.Temperature = NewTemperature(*db.Temperature, unit)
+		}
+		b := ScheduleBlock{
+			DayType: dayType,
+			Start:   starts[i].Format("15:04"),
+			End:     end,
+			Setting: s,
+		}
+		if i < len(current) && current[i].Start == b.Start && current[i].End == b.End && current[i].Setting.Power == s.Power &&
+			current[i].Setting.Temperature.Equal(s.Temperature) {
+			b = current[i]
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+func containsString(s []string, v string) bool {
+	for _, sv := range s {
+		if sv == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package tado
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDesiredStateYAML = `
+homes:
+  - id: 12345
+    awayRadiusInMeters: 1000
+    zones:
+      - name: living room
+        schedule:
+          days:
+            MONDAY_TO_SUNDAY:
+              - start: "00:00"
+                temperature: 17
+              - start: "07:00"
+                temperature: 20.5
+              - start: "23:00"
+        awayTemperature: 15
+        openWindowTimeoutInSeconds: 0
+        earlyStart: true
+        temperatureOffset: -0.5
+      - name: Attic
+`
+
+func TestReadDesiredState(t *testing.T) {
+	ds, err := ReadDesiredState(strings.NewReader(testDesiredStateYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, ds.Homes, 1) {
+		h := ds.Homes[0]
+		assert.Equal(t, 12345, h.ID)
+		if assert.NotNil(t, h.AwayRadiusInMeters) {
+			assert.Equal(t, 1000.0, *h.AwayRadiusInMeters)
+		}
+		if assert.Len(t, h.Zones, 2) {
+			z := h.Zones[0]
+			assert.Equal(t, "living room", z.Name)
+			assert.Len(t, z.Schedule.Days["MONDAY_TO_SUNDAY"], 3)
+			assert.Nil(t, z.Schedule.Days["MONDAY_TO_SUNDAY"][2].Temperature)
+			if assert.NotNil(t, z.OpenWindowTimeoutInSeconds) {
+				assert.Equal(t, 0, *z.OpenWindowTimeoutInSeconds)
+			}
+		}
+	}
+
+	ds, err = ReadDesiredState(strings.NewReader(`  {"homes": [{"id": 1, "temperatureUnit": "FAHRENHEIT", "zones": [{"id": 2, "name": "Hall"}]}]}`))
+	if assert.NoError(t, err) && assert.Len(t, ds.Homes, 1) {
+		assert.Equal(t, TemperatureUnitFahrenheit, ds.Homes[0].TemperatureUnit)
+		assert.Equal(t, DesiredZone{ID: 2, Name: "Hall"}, ds.Homes[0].Zones[0])
+	}
+
+	_, err = ReadDesiredState(strings.NewReader(`{"homes": [{"unknown": true}]}`))
+	assert.Error(t, err)
+
+	// misspelled YAML keys are rejected as well
+	_, err = ReadDesiredState(strings.NewReader("homes:\n  - id: 1\n    zones:\n      - id: 2\n        awayTemprature: 15\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "field awayTemprature not found")
+	}
+}
+
+func TestClient_PlanReconcile_FahrenheitOffset(t *testing.T) {
+	var changes []string
+	client, server := setupTestClientAndServer(snapshotTestHandler(t, &changes))
+	defer server.Close()
+
+	offset := func(f float64) *DesiredState {
+		return &DesiredState{Homes: []DesiredHome{{
+			ID:              12345,
+			TemperatureUnit: TemperatureUnitFahrenheit,
+			Zones:           []DesiredZone{{ID: 1, TemperatureOffset: &f}},
+		}}}
+	}
+
+	// the current offset is 0, which is 0°F and not 32°F
+	plans, err := client.PlanReconcile(context.Background(), offset(0))
+	if assert.NoError(t, err) && assert.Len(t, plans, 1) {
+		assert.True(t, plans[0].Empty())
+	}
+
+	plans, err = client.Reconcile(context.Background(), offset(1.8))
+	if assert.NoError(t, err) && assert.Len(t, plans, 1) {
+		assert.Equal(t, "home 12345: 1 change(s)\n~ device VA01: temperature offset: 0°C -> 1°C\n", plans[0].String())
+	}
+	assert.Equal(t, []string{`PUT /v2/devices/VA01/temperatureOffset {"celsius":1}`}, changes)
+}
+
+func TestClient_Reconcile(t *testing.T) {
+	var changes []string
+	client, server := setupTestClientAndServer(snapshotTestHandler(t, &changes))
+	defer server.Close()
+
+	ds, err := ReadDesiredState(strings.NewReader(testDesiredStateYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plans, err := client.PlanReconcile(context.Background(), ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, changes, "PlanReconcile must not change anything")
+	if assert.Len(t, plans, 1) {
+		assert.Equal(t, `home 12345: 4 change(s)
+~ home 12345: away radius: 400m -> 1000m
+~ zone 1 "Living Room": open window detection: enabled for 15m0s -> disabled
+~ zone 1 "Living Room": schedule MONDAY_TO_SUNDAY: 00:00-00:00 HEATING ON 18°C -> 00:00-07:00 HEATING ON 17°C, 07:00-23:00 HEATING ON 20.5°C, 23:00-00:00 HEATING OFF
+~ device VA01: temperature offset: 0°C -> -0.5°C
+! skipped zone 0 "Attic", it does not exist
+`, plans[0].String())
+	}
+
+	plans, err = client.Reconcile(context.Background(), ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, plans, 1)
+	assert.Equal(t, []string{
+		`PUT /v2/homes/12345/awayRadiusInMeters {"awayRadiusInMeters":1000}`,
+		`PUT /v2/homes/12345/zones/1/openWindowDetection {"enabled":false}`,
+		`PUT /v2/homes/12345/zones/1/schedule/timetables/0/blocks/MONDAY_TO_SUNDAY [{"dayType":"MONDAY_TO_SUNDAY","start":"00:00","end":"07:00","geolocationOverride":false,"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":17,"fahrenheit":62.6}}},{"dayType":"MONDAY_TO_SUNDAY","start":"07:00","end":"23:00","geolocationOverride":false,"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":20.5,"fahrenheit":68.9}}},{"dayType":"MONDAY_TO_SUNDAY","start":"23:00","end":"00:00","geolocationOverride":false,"setting":{"type":"HEATING","power":"OFF","temperature":{}}}]`,
+		`PUT /v2/devices/VA01/temperatureOffset {"celsius":-0.5}`,
+	}, changes)
+}
+
+func TestDesiredHome_Errors(t *testing.T) {
+	current := &Snapshot{
+		Zones: []ZoneSnapshot{
+			{Zone: Zone{ID: 1, Name: "Bedroom", Type: ZoneTypeHeating}, ActiveTimetable: Timetable{ID: 0, Type: TimetableTypeOneDay}},
+			{Zone: Zone{ID: 2, Name: "bedroom", Type: ZoneTypeHeating}, ActiveTimetable: Timetable{ID: 0, Type: TimetableTypeOneDay}},
+		},
+	}
+
+	tests := []struct {
+		zone DesiredZone
+		err  string
+	}{
+		{DesiredZone{Name: "BEDROOM"}, `zone name "BEDROOM" is ambiguous, set the zone ID`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Timetable: "FOUR_DAY"}}, `zone 1 "Bedroom": unknown timetable "FOUR_DAY"`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY": {{Start: "00:00"}}}}},
+			`zone 1 "Bedroom": day type MONDAY is not part of timetable ONE_DAY`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY_TO_SUNDAY": {{Start: "06:00"}}}}},
+			`zone 1 "Bedroom": the first block of MONDAY_TO_SUNDAY must start at 00:00`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY_TO_SUNDAY": {{Start: "00:00"}, {Start: "08:00"}, {Start: "07:00"}}}}},
+			`zone 1 "Bedroom": blocks of MONDAY_TO_SUNDAY must be in order, 07:00 starts before 08:00`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY_TO_SUNDAY": {{Start: "00:00"}, {Start: "10:00"}, {Start: "7:30"}}}}},
+			`zone 1 "Bedroom": blocks of MONDAY_TO_SUNDAY must be in order, 7:30 starts before 10:00`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY_TO_SUNDAY": {{Start: "00:00"}, {Start: "25:99"}}}}},
+			`zone 1 "Bedroom": invalid start "25:99" in MONDAY_TO_SUNDAY, use HH:MM`},
+		{DesiredZone{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY_TO_SUNDAY": {{Start: "abc"}}}}},
+			`zone 1 "Bedroom": invalid start "abc" in MONDAY_TO_SUNDAY, use HH:MM`},
+	}
+	for _, tt := range tests {
+		dh := DesiredHome{ID: 1, Zones: []DesiredZone{tt.zone}}
+		_, _, err := dh.apply(current)
+		if assert.Error(t, err) {
+			assert.Equal(t, tt.err, err.Error())
+		}
+	}
+
+	// zones without away configuration can not get an away temperature
+	away := 16.0
+	ac := &Snapshot{Zones: []ZoneSnapshot{{Zone: Zone{ID: 3, Name: "Office", Type: ZoneTypeAirConditioning}}}}
+	dh := DesiredHome{ID: 1, Zones: []DesiredZone{{ID: 3, AwayTemperature: &away}}}
+	wanted, _, err := dh.apply(ac)
+	if assert.NoError(t, err) {
+		p := diffSnapshots(ac, wanted)
+		assert.Empty(t, p.Changes)
+		assert.Equal(t, []string{`zone 3 "Office": away configuration, the zone has none`}, p.Skipped)
+	}
+
+	// start times are sent as HH:MM
+	dh = DesiredHome{ID: 1, Zones: []DesiredZone{{ID: 1, Schedule: &DesiredSchedule{Days: map[string][]DesiredBlock{"MONDAY_TO_SUNDAY": {{Start: "0:00"}, {Start: "7:30"}}}}}}}
+	wanted, _, err = dh.apply(current)
+	if assert.NoError(t, err) && assert.Len(t, wanted.Zones[0].Blocks, 2) {
+		assert.Equal(t, "00:00", wanted.Zones[0].Blocks[0].Start)
+		assert.Equal(t, "07:30", wanted.Zones[0].Blocks[0].End)
+		assert.Equal(t, "07:30", wanted.Zones[0].Blocks[1].Start)
+	}
+}
@@ -0,0 +1,80 @@
+package tado
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+)
+
+// DryRunRequest is a mutating request that was not sent because the client is in dry-run mode.
+type DryRunRequest struct {
+	Time   time.Time
+	Method string
+	Path   string
+	Body   json.RawMessage
+}
+
+// isMutating returns true for all methods that can change something at Tado
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// DryRunRequests returns all requests recorded in dry-run mode.
+func (c *Client) DryRunRequests() []DryRunRequest {
+	c.dryRunMutex.Lock()
+	defer c.dryRunMutex.Unlock()
+	return append([]DryRunRequest(nil), c.dryRunRequests...)
+}
+
+// ResetDryRunRequests removes all requests recorded in dry-run mode.
+func (c *Client) ResetDryRunRequests() {
+	c.dryRunMutex.Lock()
+	defer c.dryRunMutex.Unlock()
+	c.dryRunRequests = nil
+}
+
+// dryRunInterceptor records mutating requests instead of passing them to next, and logs them at info level.
+// The request body is decoded into the output as synthetic response, which matches the real response for most
+// endpoints since Tado echoes the changed resource. Outputs that cannot be decoded from the body are left empty.
+func (c *Client) dryRunInterceptor(next Doer) Doer {
+	return DoerFunc(func(call *Call) error {
+		if !isMutating(call.Method) {
+			return next.Do(call)
+		}
+
+		r := DryRunRequest{
+			Time:   time.Now(),
+			Method: call.Method,
+			Path:   call.Path,
+		}
+		if call.Body != nil {
+			r.Body = bytes.TrimSpace(call.Body)
+		}
+
+		c.dryRunMutex.Lock()
+		c.dryRunRequests = append(c.dryRunRequests, r)
+		c.dryRunMutex.Unlock()
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.Path),
+		}
+		if len(r.Body) > 0 {
+			attrs = append(attrs, slog.String("body", tadoauth.RedactJSON(string(r.Body))))
+		}
+		c.log(slog.LevelInfo, "tado dry-run", attrs...)
+
+		if len(r.Body) > 0 && call.Output != nil {
+			_ = json.Unmarshal(r.Body, call.Output)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package tado
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	var requests []string
+	f := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"id": 1, "name": "Living Room"}]`)
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	logs := new(bytes.Buffer)
+	client.DryRun = true
+	client.SetLogger(newTestLogger(logs, slog.LevelInfo))
+
+	// GET requests are sent
+	_, err := client.GetZones(&GetZonesInput{HomeID: 12345})
+	assert.NoError(t, err)
+
+	// mutating requests are recorded
+	out, err := client.PutOverlay(&PutOverlayInput{
+		HomeID: 12345,
+		ZoneID: 1,
+		OverlayInput: OverlayInput{
+			Setting: Setting{
+				Type:        ZoneTypeHeating,
+				Power:       PowerOn,
+				Temperature: Temperature{Celsius: 21},
+			},
+			Termination: OverlayInputTermination{Type: TerminationTypeManual},
+		},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, PowerOn, out.Setting.Power)
+		assert.Equal(t, 21.0, out.Setting.Temperature.Celsius)
+	}
+	_, err = client.DeleteOverlay(&DeleteOverlayInput{HomeID: 12345, ZoneID: 1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"GET /v2/homes/12345/zones"}, requests)
+
+	recorded := client.DryRunRequests()
+	if assert.Len(t, recorded, 2) {
+		assert.Equal(t, http.MethodPut, recorded[0].Method)
+		assert.Equal(t, "/v2/homes/12345/zones/1/overlay", recorded[0].Path)
+		assert.Equal(t, `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":21}},"termination":{"type":"MANUAL"}}`, string(recorded[0].Body))
+		assert.True(t, json.Valid(recorded[0].Body))
+		assert.False(t, recorded[0].Time.IsZero())
+		assert.Equal(t, http.MethodDelete, recorded[1].Method)
+		assert.Nil(t, recorded[1].Body)
+	}
+	assert.Contains(t, logs.String(), `level=INFO msg="tado dry-run" method=PUT path=/v2/homes/12345/zones/1/overlay body="{\"setting\":{\"type\":\"HEATING\",\"power\":\"ON\",\"temperature\":{\"celsius\":21}},\"termination\":{\"type\":\"MANUAL\"}}"`+"\n")
+	assert.Contains(t, logs.String(), `level=INFO msg="tado dry-run" method=DELETE path=/v2/homes/12345/zones/1/overlay`+"\n")
+
+	client.ResetDryRunRequests()
+	assert.Empty(t, client.DryRunRequests())
+
+	// disable dry-run, mutating requests are sent again
+	client.DryRun = false
+	_, err = client.DeleteOverlay(&DeleteOverlayInput{HomeID: 12345, ZoneID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET /v2/homes/12345/zones", "DELETE /v2/homes/12345/zones/1/overlay"}, requests)
+	assert.Empty(t, client.DryRunRequests())
+}
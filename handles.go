@@ -0,0 +1,212 @@
+package tado
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HomeHandle is a handle to a single home, it wraps the Client methods that need a home ID.
+// Use Client.Home or Client.Homes to get one.
+// The zones and devices of the home are loaded on first use and cached, use Refresh to load them again.
+type HomeHandle struct {
+	ID   int
+	Name string
+
+	client  *Client
+	mutex   sync.Mutex
+	zones   []*ZoneHandle
+	devices []Device
+}
+
+// ZoneHandle is a handle to a single zone within a home, use HomeHandle.Zone or HomeHandle.Zones to get one.
+// It contains the zone info as it was when the zones of the home were loaded.
+type ZoneHandle struct {
+	Zone
+
+	home *HomeHandle
+}
+
+// Home returns a handle to the home with id, no request is made until the handle is used.
+func (c *Client) Home(id int) *HomeHandle {
+	return &HomeHandle{
+		ID:     id,
+		client: c,
+	}
+}
+
+// Homes returns a handle to every home the user has access to.
+func (c *Client) Homes() ([]*HomeHandle, error) {
+	me, err := c.GetMe()
+	if err != nil {
+		return nil, err
+	}
+	homes := make([]*HomeHandle, 0, len(me.Homes))
+	for _, h := range me.Homes {
+		home := c.Home(h.ID)
+		home.Name = h.Name
+		homes = append(homes, home)
+	}
+	return homes, nil
+}
+
+// Home returns the Home data.
+func (h *HomeHandle) Home() (*Home, error) {
+	out, err := h.client.GetHome(&GetHomeInput{HomeID: h.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &out.Home, nil
+}
+
+// State returns the presence state of the home.
+func (h *HomeHandle) State() (*HomeState, error) {
+	out, err := h.client.GetHomeState(&GetHomeStateInput{HomeID: h.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &out.HomeState, nil
+}
+
+// Refresh clears the cached zones and devices, they are loaded again on next use.
+func (h *HomeHandle) Refresh() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.zones = nil
+	h.devices = nil
+}
+
+// Zones returns a handle to every zone in the home.
+// The returned slice is a copy, the zone handles are shared with other callers.
+func (h *HomeHandle) Zones() ([]*ZoneHandle, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.zones != nil {
+		return append([]*ZoneHandle(nil), h.zones...), nil
+	}
+	zones, err := h.client.GetZones(&GetZonesInput{HomeID: h.ID})
+	if err != nil {
+		return nil, err
+	}
+	h.zones = make([]*ZoneHandle, 0, len(zones))
+	for _, z := range zones {
+		h.zones = append(h.zones, &ZoneHandle{Zone: z, home: h})
+	}
+	return append([]*ZoneHandle(nil), h.zones...), nil
+}
+
+// Zone returns the zone with name, names are compared case-insensitive.
+// An error is returned when no zone or more than one zone has this name.
+func (h *HomeHandle) Zone(name string) (*ZoneHandle, error) {
+	zones, err := h.Zones()
+	if err != nil {
+		return nil, err
+	}
+	var found []*ZoneHandle
+	for _, z := range zones {
+		if strings.EqualFold(strings.TrimSpace(z.Name), strings.TrimSpace(name)) {
+			found = append(found, z)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no zone named %q in home %d", name, h.ID)
+	case 1:
+		return found[0], nil
+	}
+	ids := make([]int, 0, len(found))
+	for _, z := range found {
+		ids = append(ids, z.ID)
+	}
+	return nil, fmt.Errorf("zone name %q is ambiguous in home %d, it matches zones %v", name, h.ID, ids)
+}
+
+// ZoneByID returns the zone with id.
+func (h *HomeHandle) ZoneByID(id int) (*ZoneHandle, error) {
+	zones, err := h.Zones()
+	if err != nil {
+		return nil, err
+	}
+	for _, z := range zones {
+		if z.ID == id {
+			return z, nil
+		}
+	}
+	return nil, fmt.Errorf("no zone with ID %d in home %d", id, h.ID)
+}
+
+// Devices returns the devices in the home.
+func (h *HomeHandle) Devices() ([]Device, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.devices != nil {
+		return append([]Device(nil), h.devices...), nil
+	}
+	devices, err := h.client.GetDevices(&GetDevicesInput{HomeID: h.ID})
+	if err != nil {
+		return nil, err
+	}
+	h.devices = devices
+	return append([]Device(nil), h.devices...), nil
+}
+
+// Home returns the handle of the home the zone is in.
+func (z *ZoneHandle) Home() *HomeHandle {
+	return z.home
+}
+
+// State returns the current state of the zone.
+func (z *ZoneHandle) State() (*ZoneState, error) {
+	out, err := z.home.client.GetZoneState(&GetZoneStateInput{HomeID: z.home.ID, ZoneID: z.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &out.ZoneState, nil
+}
+
+// Capabilities returns the supported settings and temperature range of the zone.
+func (z *ZoneHandle) Capabilities() (*ZoneCapabilities, error) {
+	out, err := z.home.client.GetZoneCapabilities(&GetZoneCapabilitiesInput{HomeID: z.home.ID, ZoneID: z.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &out.ZoneCapabilities, nil
+}
+
+// SetTemperature turns the heating on at a temperature in degrees Celsius until the schedule is resumed.
+func (z *ZoneHandle) SetTemperature(celsius float64) (*Overlay, error) {
+	return z.SetOverlay(z.Overlay().Heating(celsius))
+}
+
+// TurnOff turns the heating, air conditioning or hot water off until the schedule is resumed.
+func (z *ZoneHandle) TurnOff() (*Overlay, error) {
+	ob := z.Overlay().Off()
+	if z.Type != "" {
+		ob.setting.Type = z.Type
+	}
+	return z.SetOverlay(ob)
+}
+
+// Overlay returns a new OverlayBuilder for the zone, use SetOverlay to apply it.
+func (z *ZoneHandle) Overlay() *OverlayBuilder {
+	return NewOverlay(z.home.ID, z.ID)
+}
+
+// SetOverlay builds and sets the overlay from ob.
+func (z *ZoneHandle) SetOverlay(ob *OverlayBuilder) (*Overlay, error) {
+	in, err := ob.Build()
+	if err != nil {
+		return nil, err
+	}
+	out, err := z.home.client.PutOverlay(in)
+	if err != nil {
+		return nil, err
+	}
+	return &out.Overlay, nil
+}
+
+// ResumeSchedule removes the overlay of the zone so it follows its schedule again.
+func (z *ZoneHandle) ResumeSchedule() error {
+	_, err := z.home.client.DeleteOverlay(&DeleteOverlayInput{HomeID: z.home.ID, ZoneID: z.ID})
+	return err
+}
@@ -0,0 +1,171 @@
+package tado
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Homes(t *testing.T) {
+
+	f := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/me", r.URL.Path)
+		_, _ = fmt.Fprint(w, `{"homes": [{"id": 1, "name": "Home"}, {"id": 2, "name": "Beach house"}]}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	homes, err := client.Homes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, homes, 2) {
+		assert.Equal(t, 1, homes[0].ID)
+		assert.Equal(t, "Home", homes[0].Name)
+		assert.Equal(t, 2, homes[1].ID)
+		assert.Equal(t, "Beach house", homes[1].Name)
+	}
+}
+
+func TestHomeHandle_Zone(t *testing.T) {
+
+	zoneCalls := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/homes/12345/zones", r.URL.Path)
+		zoneCalls++
+		_, _ = fmt.Fprint(w, `[{"id": 1, "name": "Living Room"}, {"id": 2, "name": "Bedroom"}, {"id": 3, "name": "bedroom "}]`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	home := client.Home(12345)
+
+	z, err := home.Zone("living room")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, z.ID)
+		assert.Equal(t, "Living Room", z.Name)
+		assert.Equal(t, home, z.Home())
+	}
+
+	_, err = home.Zone("Bedroom")
+	if assert.Error(t, err) {
+		assert.Equal(t, `zone name "Bedroom" is ambiguous in home 12345, it matches zones [2 3]`, err.Error())
+	}
+
+	_, err = home.Zone("Kitchen")
+	if assert.Error(t, err) {
+		assert.Equal(t, `no zone named "Kitchen" in home 12345`, err.Error())
+	}
+
+	z, err = home.ZoneByID(3)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "bedroom ", z.Name)
+	}
+
+	// changing the returned slice does not change the cached zones
+	zones, err := home.Zones()
+	if assert.NoError(t, err) && assert.Len(t, zones, 3) {
+		zones[0] = nil
+		z, err = home.ZoneByID(1)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Living Room", z.Name)
+		}
+	}
+
+	// zones are loaded once until the home is refreshed
+	assert.Equal(t, 1, zoneCalls)
+	home.Refresh()
+	_, err = home.Zones()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, zoneCalls)
+}
+
+func TestZoneHandle(t *testing.T) {
+
+	var requests []string
+	f := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v2/homes/12345/zones":
+			_, _ = fmt.Fprint(w, `[{"id": 4, "name": "Kitchen", "type": "HEATING"}]`)
+		case "/v2/homes/12345/zones/4/state":
+			_, _ = fmt.Fprint(w, `{"tadoMode": "AWAY"}`)
+		case "/v2/homes/12345/zones/4/overlay":
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":19.5}},"termination":{"type":"MANUAL"}}`+"\n", string(b))
+			_, _ = fmt.Fprint(w, `{"type": "MANUAL", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 19.5}}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	z, err := client.Home(12345).Zone("KITCHEN")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := z.State()
+	if assert.NoError(t, err) {
+		assert.Equal(t, TadoModeAway, s.TadoMode)
+	}
+
+	o, err := z.SetTemperature(19.5)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 19.5, o.Setting.Temperature.Celsius)
+	}
+
+	assert.NoError(t, z.ResumeSchedule())
+
+	assert.Equal(t, []string{
+		"GET /v2/homes/12345/zones",
+		"GET /v2/homes/12345/zones/4/state",
+		"PUT /v2/homes/12345/zones/4/overlay",
+		"DELETE /v2/homes/12345/zones/4/overlay",
+	}, requests)
+}
+
+func TestZoneHandle_TurnOff(t *testing.T) {
+
+	overlays := map[string]string{}
+	f := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/homes/12345/zones":
+			_, _ = fmt.Fprint(w, `[{"id": 1, "name": "Living Room", "type": "HEATING"}, {"id": 2, "name": "Office", "type": "AIR_CONDITIONING"}, {"id": 3, "name": "Hot Water", "type": "HOT_WATER"}]`)
+		default:
+			b, _ := ioutil.ReadAll(r.Body)
+			overlays[r.URL.Path] = string(b)
+			_, _ = fmt.Fprint(w, `{"type": "MANUAL"}`)
+		}
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	zones, err := client.Home(12345).Zones()
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, z := range zones {
+		_, err = z.TurnOff()
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, map[string]string{
+		"/v2/homes/12345/zones/1/overlay": `{"setting":{"type":"HEATING","power":"OFF","temperature":{}},"termination":{"type":"MANUAL"}}` + "\n",
+		"/v2/homes/12345/zones/2/overlay": `{"setting":{"type":"AIR_CONDITIONING","power":"OFF","temperature":{}},"termination":{"type":"MANUAL"}}` + "\n",
+		"/v2/homes/12345/zones/3/overlay": `{"setting":{"type":"HOT_WATER","power":"OFF","temperature":{}},"termination":{"type":"MANUAL"}}` + "\n",
+	}, overlays)
+}
@@ -7,10 +7,21 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 const defaultBaseURL = "https://my.tado.com/api"
 
+// HTTPError is the error type returned when the Tado API responds with an error status.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (he *HTTPError) Error() string {
+	return fmt.Sprintf("error: HTTP status %d: %s", he.StatusCode, he.Body)
+}
+
 type input interface {
 	method() string
 	path() string
@@ -18,65 +29,118 @@ type input interface {
 }
 
 func (c *Client) do(in input, out interface{}) error {
-	// ensure accesstoken is still valid
-	err := c.validateAccessToken()
+	call, err := newCall(in, out, c.baseURL)
 	if err != nil {
 		return err
 	}
 
-	// encode input as JSON if needed
+	start := time.Now()
+	err = c.doer().Do(call)
+	if err == nil {
+		err = call.decode()
+	}
+	c.logCall(call, time.Since(start), err)
+
+	// only mutating requests are audited
+	if c.AuditSink != nil && isMutating(call.Method) {
+		c.audit(call, time.Since(start), err)
+	}
+	return err
+}
+
+// newCall returns the call for in, with the input encoded as JSON if needed
+func newCall(in input, out interface{}, baseURL string) (*Call, error) {
+	call := &Call{
+		Input:  in,
+		Output: out,
+		Method: in.method(),
+		Path:   in.path(),
+	}
+
 	var body io.Reader
-	switch in.method() {
+	switch call.Method {
 	case http.MethodPost, http.MethodPut:
 		buf := new(bytes.Buffer)
 		err := json.NewEncoder(buf).Encode(in.body())
 		if err != nil {
-			return fmt.Errorf("error encoding input: %s", err)
+			return nil, fmt.Errorf("error encoding input: %s", err)
 		}
-		body = buf
+		call.Body = buf.Bytes()
+		body = bytes.NewReader(call.Body)
 	}
 
 	// create HTTP request
-	req, err := http.NewRequest(in.method(), c.baseURL+in.path(), body)
+	req, err := http.NewRequest(call.Method, baseURL+call.Path, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// set authentication header
-	req.Header.Set("Authorization", "Bearer "+c.tr.AccessToken)
-
 	// set content type if needed
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	call.Request = req
+
+	return call, nil
+}
+
+// doer returns the interceptor chain around send
+func (c *Client) doer() Doer {
+	d := Doer(DoerFunc(c.send))
+	if c.budget != nil {
+		d = c.budget.interceptor(d)
+	}
+	if c.DryRun {
+		d = c.dryRunInterceptor(d)
+	}
+	if c.Cache != nil {
+		d = c.Cache.interceptor(d)
+	}
+	if c.CoalesceRequests {
+		d = c.coalescer.interceptor(d)
+	}
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		d = c.Interceptors[i](d)
+	}
+	return d
+}
+
+// send is the innermost Doer, it authenticates and executes the HTTP request of the call
+func (c *Client) send(call *Call) error {
+	// ensure accesstoken is still valid
+	accessToken, refresh, err := c.validateAccessToken()
+	call.TokenRefresh = refresh
+	if err != nil {
+		return err
+	}
+
+	// set authentication header
+	call.Request.Header.Set("Authorization", "Bearer "+accessToken)
 
 	// execute HTTP request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.HTTPClient.Do(call.Request)
 	if err != nil {
 		return fmt.Errorf("HTTP error: %s", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// check HTTP status
+	// read the body so interceptors can use it after the response is closed
+	var r io.Reader = resp.Body
 	if resp.StatusCode >= http.StatusBadRequest {
-		// not OK, read body
-		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<14))
-		if err != nil {
-			return fmt.Errorf("HTTP error: %s", err)
-		}
-		// return the body as error
-		return fmt.Errorf("error: HTTP status %d: %s", resp.StatusCode, string(body))
+		r = io.LimitReader(resp.Body, 1<<14)
 	}
-
-	// for NoContent we do not decode any JSON
-	if resp.StatusCode == http.StatusNoContent {
-		return nil
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %s", err)
 	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	call.Response = resp
+	call.ResponseBody = body
 
-	// OK response, decode into output
-	err = json.NewDecoder(resp.Body).Decode(out)
-	if err != nil {
-		return fmt.Errorf("error decoding output: %s", err)
+	// check HTTP status
+	if resp.StatusCode >= http.StatusBadRequest {
+		// return the body as error
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return nil
@@ -60,8 +60,7 @@ func TestClient_Do(t *testing.T) {
 	}
 	// Create mock Tado client
 	c := NewClient("", "")
-	c.tr = tr
-	c.accessTokenValidUntil = time.Now().Add(time.Minute)
+	c.setToken(tr, time.Now().Add(time.Minute))
 
 	// Prepare fake output
 	out := new(testOut)
@@ -96,6 +95,9 @@ func TestClient_Do(t *testing.T) {
 	assert.Equal(t, "Bearer thisIsAFakeToken", incomingRequest.Header.Get("Authorization"))
 	if assert.Error(t, err) {
 		assert.Equal(t, "error: HTTP status 400: Bad request", err.Error())
+		if assert.IsType(t, new(HTTPError), err) {
+			assert.Equal(t, http.StatusBadRequest, err.(*HTTPError).StatusCode)
+		}
 	}
 
 	// Test a GET method returning an OK status with invalid JSON
@@ -138,15 +140,41 @@ func TestInputMethods(t *testing.T) {
 	testStructs := []input{
 		new(GetMeInput),
 		new(GetHomeInput),
+		new(PutHomeDetailsInput),
+		new(PutAwayRadiusInput),
 		new(GetHomeStateInput),
 		new(GetDevicesInput),
 		new(GetZonesInput),
+		new(PutZoneDetailsInput),
+		new(PutDazzleModeInput),
+		new(PutZoneOrderInput),
+		new(PostZoneDeviceInput),
 		new(GetZoneStateInput),
+		new(GetZoneStatesInput),
+		new(GetZoneCapabilitiesInput),
 		new(GetUsersInput),
+		new(DeleteUserInput),
+		new(GetInvitationsInput),
+		new(PostInvitationInput),
+		new(ResendInvitationInput),
+		new(DeleteInvitationInput),
+		new(GetEarlyStartInput),
+		new(PutEarlyStartInput),
+		new(PutOpenWindowDetectionInput),
+		new(GetActiveTimetableInput),
+		new(PutActiveTimetableInput),
+		new(GetScheduleBlocksInput),
+		new(PutScheduleBlocksInput),
+		new(GetAwayConfigurationInput),
+		new(PutAwayConfigurationInput),
+		new(GetTemperatureOffsetInput),
+		new(PutTemperatureOffsetInput),
 		new(GetWeatherInput),
 		new(GetDayReportInput),
 		new(PutOverlayInput),
 		new(DeleteOverlayInput),
+		new(PostOverlaysInput),
+		new(DeleteOverlaysInput),
 	}
 
 	for _, s := range testStructs {
@@ -0,0 +1,151 @@
+package tado
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Call is a single API call passing through the interceptor chain of a Client.
+type Call struct {
+	// Input is the typed input of the call, for example *GetZoneStateInput.
+	Input interface{}
+	// Output is the value the response body is decoded into after the chain returns.
+	Output interface{}
+	Method string
+	Path   string
+	// Body is the JSON encoded request body, nil for methods without body.
+	Body []byte
+	// Request is the HTTP request that will be sent, the authorization header is set by the innermost Doer.
+	Request *http.Request
+	// Response and ResponseBody are set once a response is received. The response body has already been read,
+	// use ResponseBody instead. An interceptor can set ResponseBody to return a response without calling next.
+	Response     *http.Response
+	ResponseBody []byte
+	// Cached is true when the response body was served from the cache.
+	Cached bool
+	// TokenRefresh is set when the access token was acquired or refreshed for this call.
+	TokenRefresh *TokenRefresh
+}
+
+// Grant types used to acquire an access token
+const (
+	GrantTypePassword     = "password"
+	GrantTypeRefreshToken = "refresh_token"
+)
+
+// TokenRefresh describes the acquisition of a new access token.
+type TokenRefresh struct {
+	Start, End time.Time
+	// GrantType is GrantTypePassword or GrantTypeRefreshToken
+	GrantType string
+	// Reason is the reason a new access token was needed
+	Reason string
+	Err    error
+}
+
+var (
+	callHomeRegexp   = regexp.MustCompile(`/homes/(\d+)`)
+	callZoneRegexp   = regexp.MustCompile(`/zones/(\d+)`)
+	callDeviceRegexp = regexp.MustCompile(`/devices/([^/?]+)`)
+)
+
+// HomeID returns the home ID from the path, or 0 if the path does not contain a home.
+func (c *Call) HomeID() int {
+	return pathID(callHomeRegexp, c.Path)
+}
+
+// ZoneID returns the zone ID from the path, or 0 if the path does not contain a zone.
+func (c *Call) ZoneID() int {
+	return pathID(callZoneRegexp, c.Path)
+}
+
+// DeviceSerialNo returns the device serial number from the path, or "" if the path does not contain a device.
+func (c *Call) DeviceSerialNo() string {
+	m := callDeviceRegexp.FindStringSubmatch(c.Path)
+	if m == nil {
+		return ""
+	}
+	sn, err := url.PathUnescape(m[1])
+	if err != nil {
+		return m[1]
+	}
+	return sn
+}
+
+// Name returns the name of the call, which is the input type without Input suffix, for example GetZoneState.
+func (c *Call) Name() string {
+	t := reflect.TypeOf(c.Input)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return strings.TrimSuffix(t.Name(), "Input")
+}
+
+func pathID(re *regexp.Regexp, path string) int {
+	m := re.FindStringSubmatch(path)
+	if m == nil {
+		return 0
+	}
+	id, _ := strconv.Atoi(m[1])
+	return id
+}
+
+// StatusCode returns the HTTP status of the response, or 0 if there is no response.
+func (c *Call) StatusCode() int {
+	if c.Response == nil {
+		return 0
+	}
+	return c.Response.StatusCode
+}
+
+// decode decodes the response body into the output
+func (c *Call) decode() error {
+	// for NoContent or an empty response we do not decode any JSON
+	if c.StatusCode() == http.StatusNoContent || len(c.ResponseBody) == 0 || c.Output == nil {
+		return nil
+	}
+	err := json.Unmarshal(c.ResponseBody, c.Output)
+	if err != nil {
+		return fmt.Errorf("error decoding output: %s", err)
+	}
+	return nil
+}
+
+// Doer executes a Call.
+type Doer interface {
+	Do(c *Call) error
+}
+
+// DoerFunc is a function that implements Doer.
+type DoerFunc func(c *Call) error
+
+// Do calls f(c)
+func (f DoerFunc) Do(c *Call) error {
+	return f(c)
+}
+
+// Interceptor wraps a Doer, it can change the call before passing it to next, inspect the result after next
+// returns or handle the call without calling next at all.
+type Interceptor func(next Doer) Doer
+
+// HeaderInterceptor returns an Interceptor that sets header on every request.
+func HeaderInterceptor(header http.Header) Interceptor {
+	return func(next Doer) Doer {
+		return DoerFunc(func(c *Call) error {
+			for k, v := range header {
+				c.Request.Header[k] = append([]string(nil), v...)
+			}
+			return next.Do(c)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Interceptors(t *testing.T) {
+	called := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called++
+		assert.Equal(t, "test", r.Header.Get("X-Test"))
+		assert.Equal(t, "Bearer fakeToken", r.Header.Get("Authorization"))
+		_, _ = fmt.Fprint(w, `{"tadoMode": "HOME"}`)
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	var order []string
+	logging := func(name string) Interceptor {
+		return func(next Doer) Doer {
+			return DoerFunc(func(c *Call) error {
+				order = append(order, name+" before")
+				err := next.Do(c)
+				order = append(order, fmt.Sprintf("%s after %d %s", name, c.StatusCode(), c.ResponseBody))
+				return err
+			})
+		}
+	}
+	var inputs []interface{}
+	inspect := func(next Doer) Doer {
+		return DoerFunc(func(c *Call) error {
+			inputs = append(inputs, c.Input)
+			assert.Equal(t, http.MethodGet, c.Method)
+			assert.Equal(t, "/v2/homes/1/zones/2/state", c.Path)
+			assert.Equal(t, c.Method, c.Request.Method)
+			assert.Empty(t, c.Request.Header.Get("Authorization"), "authorization is set by the innermost doer")
+			return next.Do(c)
+		})
+	}
+	client.Interceptors = []Interceptor{
+		logging("outer"),
+		HeaderInterceptor(http.Header{"X-Test": {"test"}}),
+		inspect,
+		logging("inner"),
+	}
+
+	in := &GetZoneStateInput{HomeID: 1, ZoneID: 2}
+	out, err := client.GetZoneState(in)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TadoModeHome, out.TadoMode)
+	}
+	assert.Equal(t, 1, called)
+	assert.Equal(t, []interface{}{in}, inputs)
+	assert.Equal(t, []string{
+		"outer before",
+		"inner before",
+		`inner after 200 {"tadoMode": "HOME"}`,
+		`outer after 200 {"tadoMode": "HOME"}`,
+	}, order)
+
+	// an interceptor can respond without calling next
+	client.Interceptors = []Interceptor{
+		func(next Doer) Doer {
+			return DoerFunc(func(c *Call) error {
+				c.ResponseBody = []byte(`{"tadoMode": "AWAY"}`)
+				return nil
+			})
+		},
+	}
+	out, err = client.GetZoneState(in)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TadoModeAway, out.TadoMode)
+	}
+	assert.Equal(t, 1, called)
+}
+
+func TestCall_Names(t *testing.T) {
+	c := &Call{Input: &GetZoneStateInput{}, Path: "/v2/homes/12345/zones/3/state"}
+	assert.Equal(t, "GetZoneState", c.Name())
+	assert.Equal(t, 12345, c.HomeID())
+	assert.Equal(t, 3, c.ZoneID())
+	assert.Equal(t, "", c.DeviceSerialNo())
+
+	c = &Call{Input: &PutTemperatureOffsetInput{}, Path: "/v2/devices/VA%2F01/temperatureOffset"}
+	assert.Equal(t, "PutTemperatureOffset", c.Name())
+	assert.Equal(t, 0, c.HomeID())
+	assert.Equal(t, "VA/01", c.DeviceSerialNo())
+
+	assert.Equal(t, "", new(Call).Name())
+}
+
+func TestCall_TokenRefresh(t *testing.T) {
+	client, server := setupTestClientAndServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	defer server.Close()
+	client.authClient = new(mockAuthClient)
+	client.setToken(&tadoauth.TokenResponse{AccessToken: "fakeToken", RefreshToken: "refresh"}, time.Now().Add(time.Second))
+
+	var refreshes []*TokenRefresh
+	client.Interceptors = []Interceptor{
+		func(next Doer) Doer {
+			return DoerFunc(func(c *Call) error {
+				err := next.Do(c)
+				refreshes = append(refreshes, c.TokenRefresh)
+				return err
+			})
+		},
+	}
+	_, err := client.GetMe()
+	assert.NoError(t, err)
+	client.setToken(&tadoauth.TokenResponse{AccessToken: "fakeToken"}, time.Now().Add(time.Hour))
+	_, err = client.GetMe()
+	assert.NoError(t, err)
+
+	if assert.Len(t, refreshes, 2) && assert.NotNil(t, refreshes[0]) {
+		assert.Equal(t, GrantTypeRefreshToken, refreshes[0].GrantType)
+		assert.Equal(t, "expires soon", refreshes[0].Reason)
+		assert.False(t, refreshes[0].End.Before(refreshes[0].Start))
+		assert.NoError(t, refreshes[0].Err)
+		assert.Nil(t, refreshes[1])
+	}
+}
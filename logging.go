@@ -0,0 +1,73 @@
+package tado
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+)
+
+// SetLogger sets the logger used by the client, and by the authentication client when it is a tadoauth.Client.
+// Token decisions and requests are logged at info level, failed requests at error level and request and
+// response bodies at debug level. Bearer tokens, passwords and refresh tokens are never logged.
+// A nil logger disables logging, which is the default.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+	if ac, ok := c.authClient.(*tadoauth.Client); ok {
+		ac.Logger = logger
+	}
+}
+
+// log logs to the logger of the client if it is set
+func (c *Client) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// logCall logs a finished call
+func (c *Client) logCall(call *Call, duration time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	ctx := context.Background()
+	attrs := []slog.Attr{
+		slog.String("method", call.Method),
+		slog.String("path", call.Path),
+		slog.Int("status", call.StatusCode()),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		c.logger.LogAttrs(ctx, slog.LevelError, "tado request failed", append(attrs, slog.String("error", err.Error()))...)
+	} else {
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "tado request", attrs...)
+	}
+
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	debug := []slog.Attr{
+		slog.String("method", call.Method),
+		slog.String("path", call.Path),
+		slog.Any("requestHeader", redactHeader(call.Request.Header)),
+	}
+	if call.Body != nil {
+		debug = append(debug, slog.String("requestBody", tadoauth.RedactJSON(string(call.Body))))
+	}
+	if call.ResponseBody != nil {
+		debug = append(debug, slog.String("responseBody", tadoauth.RedactJSON(string(call.ResponseBody))))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "tado request dump", debug...)
+}
+
+// redactHeader returns a copy of h with the authorization header redacted
+func redactHeader(h http.Header) http.Header {
+	r := h.Clone()
+	if r.Get("Authorization") != "" {
+		r.Set("Authorization", "Bearer "+tadoauth.Redacted)
+	}
+	return r
+}
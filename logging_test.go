@@ -0,0 +1,71 @@
+package tado
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLogger returns a logger writing text without time and duration, so the output can be compared
+func newTestLogger(buf *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			switch a.Key {
+			case slog.TimeKey, "duration", "validUntil":
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestClient_SetLogger(t *testing.T) {
+	c := NewClient("username1", "password1")
+	mockAuth := &mockAuthClient{}
+	c.authClient = mockAuth
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `forbidden`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"name": "SK", "refresh_token": "secret"}`)
+	}))
+	defer s.Close()
+	c.baseURL = s.URL
+
+	buf := new(bytes.Buffer)
+	c.SetLogger(newTestLogger(buf, slog.LevelInfo))
+
+	_, err := c.GetMe()
+	assert.NoError(t, err)
+	_, err = c.PutDazzleMode(&PutDazzleModeInput{HomeID: 1, ZoneID: 2, Enabled: true})
+	assert.Error(t, err)
+
+	assert.Equal(t, `level=INFO msg="tado requesting access token" reason="no access token" username=username1
+level=INFO msg="tado access token acquired"
+level=INFO msg="tado request" method=GET path=/v2/me status=200
+level=INFO msg="tado requesting access token" reason=expired username=username1
+level=INFO msg="tado access token acquired"
+level=ERROR msg="tado request failed" method=PUT path=/v2/homes/1/zones/2/dazzle status=403 error="error: HTTP status 403: forbidden"
+`, buf.String())
+
+	// debug level dumps bodies without tokens
+	buf.Reset()
+	c.SetLogger(newTestLogger(buf, slog.LevelDebug))
+	c.setToken(&tadoauth.TokenResponse{AccessToken: "s3cr3t"}, time.Now().Add(time.Hour))
+	_, err = c.GetMe()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="tado request dump" method=GET path=/v2/me requestHeader="map[Authorization:[Bearer [REDACTED]]]" responseBody="{\"name\": \"SK\", \"refresh_token\": \"[REDACTED]\"}"`)
+	assert.NotContains(t, buf.String(), "s3cr3t")
+	assert.NotContains(t, buf.String(), "secret")
+}
@@ -8,164 +8,160 @@ import (
 
 // DayReport contains the daily report info
 type DayReport struct {
-	ZoneType string `json:"zoneType"`
-	Interval struct {
-		From time.Time `json:"from"`
-		To   time.Time `json:"to"`
-	} `json:"interval"`
-	HoursInDay   int `json:"hoursInDay"`
-	MeasuredData struct {
-		MeasuringDeviceConnected struct {
-			TimeSeriesType string `json:"timeSeriesType"`
-			ValueType      string `json:"valueType"`
-			DataIntervals  []struct {
-				From  time.Time `json:"from"`
-				To    time.Time `json:"to"`
-				Value bool      `json:"value"`
-			} `json:"dataIntervals"`
-		} `json:"measuringDeviceConnected"`
-		InsideTemperature struct {
-			TimeSeriesType string `json:"timeSeriesType"`
-			ValueType      string `json:"valueType"`
-			Min            struct {
-				Celsius    float64 `json:"celsius"`
-				Fahrenheit float64 `json:"fahrenheit"`
-			} `json:"min"`
-			Max struct {
-				Celsius    float64 `json:"celsius"`
-				Fahrenheit float64 `json:"fahrenheit"`
-			} `json:"max"`
-			DataPoints []struct {
-				Timestamp time.Time `json:"timestamp"`
-				Value     struct {
-					Celsius    float64 `json:"celsius"`
-					Fahrenheit float64 `json:"fahrenheit"`
-				} `json:"value"`
-			} `json:"dataPoints"`
-		} `json:"insideTemperature"`
-		Humidity struct {
-			TimeSeriesType string  `json:"timeSeriesType"`
-			ValueType      string  `json:"valueType"`
-			PercentageUnit string  `json:"percentageUnit"`
-			Min            float64 `json:"min"`
-			Max            float64 `json:"max"`
-			DataPoints     []struct {
-				Timestamp time.Time `json:"timestamp"`
-				Value     float64   `json:"value"`
-			} `json:"dataPoints"`
-		} `json:"humidity"`
-	} `json:"measuredData"`
-	Stripes struct {
-		TimeSeriesType string `json:"timeSeriesType"`
-		ValueType      string `json:"valueType"`
-		DataIntervals  []struct {
-			From  time.Time `json:"from"`
-			To    time.Time `json:"to"`
-			Value struct {
-				StripeType string `json:"stripeType"`
-				Setting    struct {
-					Type        string `json:"type"`
-					Power       string `json:"power"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"setting"`
-			} `json:"value"`
-		} `json:"dataIntervals"`
-	} `json:"stripes"`
-	Settings struct {
-		TimeSeriesType string `json:"timeSeriesType"`
-		ValueType      string `json:"valueType"`
-		DataIntervals  []struct {
-			From  time.Time `json:"from"`
-			To    time.Time `json:"to"`
-			Value struct {
-				Type        string `json:"type"`
-				Power       string `json:"power"`
-				Temperature struct {
-					Celsius    float64 `json:"celsius"`
-					Fahrenheit float64 `json:"fahrenheit"`
-				} `json:"temperature"`
-			} `json:"value"`
-		} `json:"dataIntervals"`
-	} `json:"settings"`
-	CallForHeat struct {
-		TimeSeriesType string `json:"timeSeriesType"`
-		ValueType      string `json:"valueType"`
-		DataIntervals  []struct {
-			From  time.Time `json:"from"`
-			To    time.Time `json:"to"`
-			Value string    `json:"value"`
-		} `json:"dataIntervals"`
-	} `json:"callForHeat"`
-	Weather struct {
-		Condition struct {
-			TimeSeriesType string `json:"timeSeriesType"`
-			ValueType      string `json:"valueType"`
-			DataIntervals  []struct {
-				From  time.Time `json:"from"`
-				To    time.Time `json:"to"`
-				Value struct {
-					State       string `json:"state"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"value"`
-			} `json:"dataIntervals"`
-		} `json:"condition"`
-		Sunny struct {
-			TimeSeriesType string `json:"timeSeriesType"`
-			ValueType      string `json:"valueType"`
-			DataIntervals  []struct {
-				From  time.Time `json:"from"`
-				To    time.Time `json:"to"`
-				Value bool      `json:"value"`
-			} `json:"dataIntervals"`
-		} `json:"sunny"`
-		Slots struct {
-			TimeSeriesType string `json:"timeSeriesType"`
-			ValueType      string `json:"valueType"`
-			Slots          struct {
-				Zero400 struct {
-					State       string `json:"state"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"04:00"`
-				Zero800 struct {
-					State       string `json:"state"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"08:00"`
-				One200 struct {
-					State       string `json:"state"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"12:00"`
-				One600 struct {
-					State       string `json:"state"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"16:00"`
-				Two000 struct {
-					State       string `json:"state"`
-					Temperature struct {
-						Celsius    float64 `json:"celsius"`
-						Fahrenheit float64 `json:"fahrenheit"`
-					} `json:"temperature"`
-				} `json:"20:00"`
-			} `json:"slots"`
-		} `json:"slots"`
-	} `json:"weather"`
+	ZoneType     ZoneType                    `json:"zoneType"`
+	Interval     Interval                    `json:"interval"`
+	HoursInDay   int                         `json:"hoursInDay"`
+	MeasuredData MeasuredData                `json:"measuredData"`
+	Stripes      IntervalSeries[Stripe]      `json:"stripes"`
+	Settings     IntervalSeries[Setting]     `json:"settings"`
+	CallForHeat  IntervalSeries[CallForHeat] `json:"callForHeat"`
+	Weather      WeatherReport               `json:"weather"`
+}
+
+// Interval is a period of time
+type Interval struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Duration returns the length of the interval.
+func (i Interval) Duration() time.Duration {
+	return i.To.Sub(i.From)
+}
+
+// TimeSeries contains the type information of a time series in a day report
+type TimeSeries struct {
+	TimeSeriesType string `json:"timeSeriesType"`
+	ValueType      string `json:"valueType"`
+}
+
+// DataInterval is a single value of a time series that applies to a period of time
+type DataInterval[T any] struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Value T         `json:"value"`
+}
+
+// Duration returns the length of the data interval.
+func (di DataInterval[T]) Duration() time.Duration {
+	return di.To.Sub(di.From)
+}
+
+// DataPoint is a single value of a time series measured at a point in time
+type DataPoint[T any] struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     T         `json:"value"`
+}
+
+// IntervalSeries is a time series of values over consecutive periods of time
+type IntervalSeries[T any] struct {
+	TimeSeries
+	DataIntervals []DataInterval[T] `json:"dataIntervals"`
+}
+
+// PointSeries is a time series of measured values
+type PointSeries[T any] struct {
+	TimeSeries
+	Min        T              `json:"min"`
+	Max        T              `json:"max"`
+	DataPoints []DataPoint[T] `json:"dataPoints"`
+}
+
+// HumiditySeries is the time series of measured humidity, values are a fraction between 0 and 1
+type HumiditySeries struct {
+	PointSeries[float64]
+	PercentageUnit string `json:"percentageUnit"`
+}
+
+// MeasuredData contains the measurements of a zone in a day report
+type MeasuredData struct {
+	MeasuringDeviceConnected IntervalSeries[bool]     `json:"measuringDeviceConnected"`
+	InsideTemperature        PointSeries[Temperature] `json:"insideTemperature"`
+	Humidity                 HumiditySeries           `json:"humidity"`
+}
+
+// StripeType is an enum type for the mode a zone was in during a part of the day
+type StripeType string
+
+const (
+	// StripeTypeHome is used when the zone followed its home schedule
+	StripeTypeHome StripeType = "HOME"
+
+	// StripeTypeAway is used when everyone was away
+	StripeTypeAway StripeType = "AWAY"
+
+	// StripeTypeSleep is used when the home was in sleep mode
+	StripeTypeSleep StripeType = "SLEEP"
+
+	// StripeTypeOverlayActive is used when an overlay was active
+	StripeTypeOverlayActive StripeType = "OVERLAY_ACTIVE"
+
+	// StripeTypeOpenWindowDetected is used when an open window was detected
+	StripeTypeOpenWindowDetected StripeType = "OPEN_WINDOW_DETECTED"
+
+	// StripeTypeMeasuringDeviceDisconnected is used when the measuring device was not connected
+	StripeTypeMeasuringDeviceDisconnected StripeType = "MEASURING_DEVICE_DISCONNECTED"
+)
+
+// IsValid returns true if st is a known StripeType.
+func (st StripeType) IsValid() bool {
+	switch st {
+	case StripeTypeHome, StripeTypeAway, StripeTypeSleep, StripeTypeOverlayActive,
+		StripeTypeOpenWindowDetected, StripeTypeMeasuringDeviceDisconnected:
+		return true
+	}
+	return false
+}
+
+// CallForHeat is an enum type for how strongly a zone requested heat during a part of the day
+type CallForHeat string
+
+const (
+	// CallForHeatNone is used when the zone did not request heat
+	CallForHeatNone CallForHeat = "NONE"
+
+	// CallForHeatLow is used when the zone requested a little heat
+	CallForHeatLow CallForHeat = "LOW"
+
+	// CallForHeatMedium is used when the zone requested a medium amount of heat
+	CallForHeatMedium CallForHeat = "MEDIUM"
+
+	// CallForHeatHigh is used when the zone requested a lot of heat
+	CallForHeatHigh CallForHeat = "HIGH"
+)
+
+// IsValid returns true if cfh is a known CallForHeat.
+func (cfh CallForHeat) IsValid() bool {
+	switch cfh {
+	case CallForHeatNone, CallForHeatLow, CallForHeatMedium, CallForHeatHigh:
+		return true
+	}
+	return false
+}
+
+// Stripe is the mode a zone was in during a part of the day
+type Stripe struct {
+	StripeType StripeType `json:"stripeType"`
+	Setting    Setting    `json:"setting"`
+}
+
+// WeatherCondition is the weather state and outside temperature
+type WeatherCondition struct {
+	State       string      `json:"state"`
+	Temperature Temperature `json:"temperature"`
+}
+
+// WeatherSlots contains the weather conditions at fixed times of the day, keyed by time (for example "08:00")
+type WeatherSlots struct {
+	TimeSeries
+	Slots map[string]WeatherCondition `json:"slots"`
+}
+
+// WeatherReport contains the weather of a day report
+type WeatherReport struct {
+	Condition IntervalSeries[WeatherCondition] `json:"condition"`
+	Sunny     IntervalSeries[bool]             `json:"sunny"`
+	Slots     WeatherSlots                     `json:"slots"`
 }
 
 // GetDayReportInput is the input for GetDayReport
@@ -3,27 +3,72 @@ package tado
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 )
 
+// BatteryState is an enum type for the battery state of a device
+type BatteryState string
+
+const (
+	// BatteryStateNormal is used when the battery of a device is fine
+	BatteryStateNormal BatteryState = "NORMAL"
+
+	// BatteryStateLow is used when the battery of a device needs to be replaced
+	BatteryStateLow BatteryState = "LOW"
+)
+
+// IsValid returns true if bs is a known BatteryState.
+func (bs BatteryState) IsValid() bool {
+	switch bs {
+	case BatteryStateNormal, BatteryStateLow:
+		return true
+	}
+	return false
+}
+
+// CapabilityInsideTemperatureMeasurement is the capability of devices that measure the inside temperature
+const CapabilityInsideTemperatureMeasurement = "INSIDE_TEMPERATURE_MEASUREMENT"
+
+// Device is a single Tado device, such as a thermostat, radiator valve or bridge
 type Device struct {
-	DeviceType       string `json:"deviceType"`
-	SerialNo         string `json:"serialNo"`
-	ShortSerialNo    string `json:"shortSerialNo"`
-	CurrentFwVersion string `json:"currentFwVersion"`
-	ConnectionState  struct {
-		Value     bool      `json:"value"`
-		Timestamp time.Time `json:"timestamp"`
-	} `json:"connectionState"`
-	Characteristics struct {
-		Capabilities []string `json:"capabilities"`
-	} `json:"characteristics"`
-	InPairingMode bool `json:"inPairingMode,omitempty"`
-	MountingState struct {
-		Value     string    `json:"value"`
-		Timestamp time.Time `json:"timestamp"`
-	} `json:"mountingState,omitempty"`
-	BatteryState string `json:"batteryState,omitempty"`
+	DeviceType       string          `json:"deviceType"`
+	SerialNo         string          `json:"serialNo"`
+	ShortSerialNo    string          `json:"shortSerialNo"`
+	CurrentFwVersion string          `json:"currentFwVersion"`
+	ConnectionState  ConnectionState `json:"connectionState"`
+	Characteristics  Characteristics `json:"characteristics"`
+	InPairingMode    bool            `json:"inPairingMode,omitempty"`
+	MountingState    MountingState   `json:"mountingState,omitempty"`
+	BatteryState     BatteryState    `json:"batteryState,omitempty"`
+	Duties           []string        `json:"duties,omitempty"`
+}
+
+// HasCapability returns true if the device has capability c.
+func (d *Device) HasCapability(c string) bool {
+	for _, dc := range d.Characteristics.Capabilities {
+		if dc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// ConnectionState tells if a device is connected
+type ConnectionState struct {
+	Value     bool      `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Characteristics contains the capabilities of a device
+type Characteristics struct {
+	Capabilities []string `json:"capabilities"`
+}
+
+// MountingState tells how a device is mounted
+type MountingState struct {
+	Value     string    `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // GetDevicesInput is the input for GetDevices
@@ -45,3 +90,54 @@ func (gdi *GetDevicesInput) body() interface{} {
 
 // GetDevicesOutput is the output for GetDevices
 type GetDevicesOutput []Device
+
+// GetTemperatureOffsetInput is the input for GetTemperatureOffset
+type GetTemperatureOffsetInput struct {
+	SerialNo string
+}
+
+func (gtoi *GetTemperatureOffsetInput) method() string {
+	return http.MethodGet
+}
+
+func (gtoi *GetTemperatureOffsetInput) path() string {
+	return fmt.Sprintf("/v2/devices/%s/temperatureOffset", url.PathEscape(gtoi.SerialNo))
+}
+
+func (gtoi *GetTemperatureOffsetInput) body() interface{} {
+	return nil
+}
+
+// GetTemperatureOffsetOutput is the output for GetTemperatureOffset
+type GetTemperatureOffsetOutput struct {
+	Temperature
+}
+
+// PutTemperatureOffsetInput is the input for PutTemperatureOffset
+type PutTemperatureOffsetInput struct {
+	SerialNo string
+	// Offset is a temperature difference, use NewTemperatureOffset to create it from Fahrenheit
+	Offset Temperature
+}
+
+func (ptoi *PutTemperatureOffsetInput) method() string {
+	return http.MethodPut
+}
+
+func (ptoi *PutTemperatureOffsetInput) path() string {
+	return fmt.Sprintf("/v2/devices/%s/temperatureOffset", url.PathEscape(ptoi.SerialNo))
+}
+
+func (ptoi *PutTemperatureOffsetInput) body() interface{} {
+	// always send Celsius, a zero offset would be omitted from a Temperature
+	return struct {
+		Celsius float64 `json:"celsius"`
+	}{
+		Celsius: ptoi.Offset.OffsetValue(TemperatureUnitCelsius),
+	}
+}
+
+// PutTemperatureOffsetOutput is the output for PutTemperatureOffset
+type PutTemperatureOffsetOutput struct {
+	Temperature
+}
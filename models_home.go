@@ -8,37 +8,69 @@ import (
 
 // Home is the info for a single home
 type Home struct {
-	ID                         int           `json:"id"`
-	Name                       string        `json:"name"`
-	DateTimeZone               string        `json:"dateTimeZone"`
-	DateCreated                time.Time     `json:"dateCreated"`
-	TemperatureUnit            string        `json:"temperatureUnit"`
-	InstallationCompleted      bool          `json:"installationCompleted"`
-	Partner                    string        `json:"partner"`
-	SimpleSmartScheduleEnabled bool          `json:"simpleSmartScheduleEnabled"`
-	AwayRadiusInMeters         float64       `json:"awayRadiusInMeters"`
-	UsePreSkillsApps           bool          `json:"usePreSkillsApps"`
-	Skills                     []interface{} `json:"skills"` // TODO
-	ChristmasModeEnabled       bool          `json:"christmasModeEnabled"`
-	ShowAutoAssistReminders    bool          `json:"showAutoAssistReminders"`
-	ContactDetails             struct {
-		Name  string `json:"name"`
-		Email string `json:"email"`
-		Phone string `json:"phone"`
-	} `json:"contactDetails"`
-	Address struct {
-		AddressLine1 string `json:"addressLine1"`
-		AddressLine2 string `json:"addressLine2"`
-		ZipCode      string `json:"zipCode"`
-		City         string `json:"city"`
-		State        string `json:"state"`
-		Country      string `json:"country"`
-	} `json:"address"`
-	Geolocation struct {
-		Latitude  float64 `json:"latitude"`
-		Longitude float64 `json:"longitude"`
-	} `json:"geolocation"`
-	ConsentGrantSkippable bool `json:"consentGrantSkippable"`
+	ID                         int             `json:"id"`
+	Name                       string          `json:"name"`
+	DateTimeZone               string          `json:"dateTimeZone"`
+	DateCreated                time.Time       `json:"dateCreated"`
+	TemperatureUnit            TemperatureUnit `json:"temperatureUnit"`
+	InstallationCompleted      bool            `json:"installationCompleted"`
+	Partner                    string          `json:"partner"`
+	SimpleSmartScheduleEnabled bool            `json:"simpleSmartScheduleEnabled"`
+	AwayRadiusInMeters         float64         `json:"awayRadiusInMeters"`
+	UsePreSkillsApps           bool            `json:"usePreSkillsApps"`
+	Skills                     []interface{}   `json:"skills"` // TODO
+	ChristmasModeEnabled       bool            `json:"christmasModeEnabled"`
+	ShowAutoAssistReminders    bool            `json:"showAutoAssistReminders"`
+	ContactDetails             ContactDetails  `json:"contactDetails"`
+	Address                    Address         `json:"address"`
+	Geolocation                Geolocation     `json:"geolocation"`
+	ConsentGrantSkippable      bool            `json:"consentGrantSkippable"`
+}
+
+// ContactDetails contains the contact details of a home
+type ContactDetails struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// Address is the postal address of a home
+type Address struct {
+	AddressLine1 string `json:"addressLine1"`
+	AddressLine2 string `json:"addressLine2"`
+	ZipCode      string `json:"zipCode"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	Country      string `json:"country"`
+}
+
+// Geolocation is the location of a home
+type Geolocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// HomeDetails contains the editable details of a home
+type HomeDetails struct {
+	Name           string         `json:"name"`
+	ContactDetails ContactDetails `json:"contactDetails"`
+	Address        Address        `json:"address"`
+	Geolocation    Geolocation    `json:"geolocation"`
+}
+
+// Details returns the editable details of the home, these can be changed and used as input for PutHomeDetails.
+func (h *Home) Details() HomeDetails {
+	return HomeDetails{
+		Name:           h.Name,
+		ContactDetails: h.ContactDetails,
+		Address:        h.Address,
+		Geolocation:    h.Geolocation,
+	}
+}
+
+// FormatTemperature formats t in the temperature unit of the home.
+func (h *Home) FormatTemperature(t Temperature) string {
+	return t.Format(h.TemperatureUnit)
 }
 
 // GetHomeInput is the input for GetHome
@@ -62,3 +94,50 @@ func (ghi *GetHomeInput) body() interface{} {
 type GetHomeOutput struct {
 	Home
 }
+
+// PutHomeDetailsInput is the input for PutHomeDetails
+type PutHomeDetailsInput struct {
+	HomeID int
+	HomeDetails
+}
+
+func (phdi *PutHomeDetailsInput) method() string {
+	return http.MethodPut
+}
+
+func (phdi *PutHomeDetailsInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/details", phdi.HomeID)
+}
+
+func (phdi *PutHomeDetailsInput) body() interface{} {
+	return phdi.HomeDetails
+}
+
+// PutHomeDetailsOutput is the output for PutHomeDetails
+type PutHomeDetailsOutput struct{}
+
+// AwayRadius contains the distance from home at which the home is considered away
+type AwayRadius struct {
+	AwayRadiusInMeters float64 `json:"awayRadiusInMeters"`
+}
+
+// PutAwayRadiusInput is the input for PutAwayRadius
+type PutAwayRadiusInput struct {
+	HomeID int
+	AwayRadius
+}
+
+func (pari *PutAwayRadiusInput) method() string {
+	return http.MethodPut
+}
+
+func (pari *PutAwayRadiusInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/awayRadiusInMeters", pari.HomeID)
+}
+
+func (pari *PutAwayRadiusInput) body() interface{} {
+	return pari.AwayRadius
+}
+
+// PutAwayRadiusOutput is the output for PutAwayRadius
+type PutAwayRadiusOutput struct{}
@@ -15,7 +15,7 @@ const (
 
 // HomeState is the state of a home
 type HomeState struct {
-	Presence string `json:"presence"`
+	Presence TadoMode `json:"presence"`
 }
 
 // GetHomeStateInput is the input for GetHomeState
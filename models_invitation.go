@@ -0,0 +1,107 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Invitation is a pending invitation for a user to join a home
+type Invitation struct {
+	Token     string    `json:"token"`
+	Email     string    `json:"email"`
+	FirstSent time.Time `json:"firstSent"`
+	LastSent  time.Time `json:"lastSent"`
+	Inviter   User      `json:"inviter"`
+	Home      Home      `json:"home"`
+}
+
+// GetInvitationsInput is the input for GetInvitations
+type GetInvitationsInput struct {
+	HomeID int
+}
+
+func (gii *GetInvitationsInput) method() string {
+	return http.MethodGet
+}
+
+func (gii *GetInvitationsInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/invitations", gii.HomeID)
+}
+
+func (gii *GetInvitationsInput) body() interface{} {
+	return nil
+}
+
+// GetInvitationsOutput is the output for GetInvitations
+type GetInvitationsOutput []Invitation
+
+// PostInvitationInput is the input for PostInvitation
+type PostInvitationInput struct {
+	HomeID int
+	Email  string
+}
+
+func (pii *PostInvitationInput) method() string {
+	return http.MethodPost
+}
+
+func (pii *PostInvitationInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/invitations", pii.HomeID)
+}
+
+func (pii *PostInvitationInput) body() interface{} {
+	return struct {
+		Email string `json:"email"`
+	}{
+		Email: pii.Email,
+	}
+}
+
+// PostInvitationOutput is the output for PostInvitation
+type PostInvitationOutput struct {
+	Invitation
+}
+
+// ResendInvitationInput is the input for ResendInvitation
+type ResendInvitationInput struct {
+	HomeID int
+	Token  string
+}
+
+func (rii *ResendInvitationInput) method() string {
+	return http.MethodPost
+}
+
+func (rii *ResendInvitationInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/invitations/%s/resend", rii.HomeID, url.PathEscape(rii.Token))
+}
+
+func (rii *ResendInvitationInput) body() interface{} {
+	return struct{}{}
+}
+
+// ResendInvitationOutput is the output for ResendInvitation
+type ResendInvitationOutput struct{}
+
+// DeleteInvitationInput is the input for DeleteInvitation
+type DeleteInvitationInput struct {
+	HomeID int
+	Token  string
+}
+
+func (dii *DeleteInvitationInput) method() string {
+	return http.MethodDelete
+}
+
+func (dii *DeleteInvitationInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/invitations/%s", dii.HomeID, url.PathEscape(dii.Token))
+}
+
+func (dii *DeleteInvitationInput) body() interface{} {
+	return nil
+}
+
+// DeleteInvitationOutput is the output for DeleteInvitation
+type DeleteInvitationOutput struct{}
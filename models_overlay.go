@@ -3,6 +3,8 @@ package tado
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,52 +20,157 @@ const (
 
 	// TerminationTypeTadoMode is used to terminate this setting by Tado, the overlay ends when the schema changes
 	TerminationTypeTadoMode TerminationType = "TADO_MODE"
+
+	// TerminationTypeNextTimeBlock is used to terminate this setting at the start of the next block of the schema
+	TerminationTypeNextTimeBlock TerminationType = "NEXT_TIME_BLOCK"
+)
+
+// IsValid returns true if tt is a known TerminationType.
+func (tt TerminationType) IsValid() bool {
+	switch tt {
+	case TerminationTypeManual, TerminationTypeTimer, TerminationTypeTadoMode, TerminationTypeNextTimeBlock:
+		return true
+	}
+	return false
+}
+
+// OverlayType is an enum type for the type of an overlay
+type OverlayType string
+
+const (
+	// OverlayTypeManual is used for overlays set by a user or by the API
+	OverlayTypeManual OverlayType = "MANUAL"
+)
+
+// IsValid returns true if ot is a known OverlayType.
+func (ot OverlayType) IsValid() bool {
+	return ot == OverlayTypeManual
+}
+
+// Power is an enum type for the power of a setting
+type Power string
+
+const (
+	// PowerOn is used when heating, hot water or air conditioning is on
+	PowerOn Power = "ON"
+
+	// PowerOff is used when heating, hot water or air conditioning is off
+	PowerOff Power = "OFF"
 )
 
+// IsValid returns true if p is a known Power.
+func (p Power) IsValid() bool {
+	switch p {
+	case PowerOn, PowerOff:
+		return true
+	}
+	return false
+}
+
 // OverlayInput is the main input for an overlay
 type OverlayInput struct {
 	Setting     OverlayInputSetting     `json:"setting"`
 	Termination OverlayInputTermination `json:"termination"`
 }
 
-// OverlayInputSetting contains the settings for the overlay
-type OverlayInputSetting struct {
-	Type        string                  `json:"type"`
-	Power       string                  `json:"power"`
-	Temperature OverlayInputTemperature `json:"temperature"`
+// Validate checks if the overlay can be sent to Tado.
+func (oi OverlayInput) Validate() error {
+	s := oi.Setting
+	if !s.Type.IsValid() {
+		return fmt.Errorf("invalid overlay: unknown setting type %q", s.Type)
+	}
+	if !s.Power.IsValid() {
+		return fmt.Errorf("invalid overlay: unknown power %q", s.Power)
+	}
+	err := oi.validateStructure()
+	if err != nil {
+		return err
+	}
+	if s.Power == PowerOn && s.Type != ZoneTypeHotWater && s.Temperature == (Temperature{}) {
+		return fmt.Errorf("invalid overlay: %s with power %s requires a temperature", s.Type, PowerOn)
+	}
+
+	t := oi.Termination
+	if !t.Type.IsValid() {
+		return fmt.Errorf("invalid overlay: unknown termination type %q", t.Type)
+	}
+	if t.Type != TerminationTypeTimer && t.DurationInSeconds != 0 {
+		return fmt.Errorf("invalid overlay: duration is only allowed with termination type %s", TerminationTypeTimer)
+	}
+	return nil
+}
+
+// validateStructure only checks the rules that do not depend on the known setting and termination types,
+// so overlays with types this package does not know yet can still be sent
+func (oi OverlayInput) validateStructure() error {
+	if oi.Setting.Power == PowerOff && oi.Setting.Temperature != (Temperature{}) {
+		return fmt.Errorf("invalid overlay: temperature set while power is %s", PowerOff)
+	}
+	if oi.Termination.Type == TerminationTypeTimer && oi.Termination.DurationInSeconds <= 0 {
+		return fmt.Errorf("invalid overlay: termination type %s requires a duration", TerminationTypeTimer)
+	}
+	return nil
 }
 
+// OverlayInputSetting contains the settings for the overlay.
+// It is an alias for Setting and kept for backwards compatibility.
+type OverlayInputSetting = Setting
+
 // OverlayInputTermination contains the termination settings for the overlay
 type OverlayInputTermination struct {
 	Type              TerminationType `json:"type"`
 	DurationInSeconds int             `json:"durationInSeconds,omitempty"`
 }
 
-// OverlayInputTemperature contains the temperature settings for the overlay
-type OverlayInputTemperature struct {
-	Celsius    float64 `json:"celsius,omitempty"`
-	Fahrenheit float64 `json:"fahrenheit,omitempty"`
+// OverlayInputTemperature contains the temperature settings for the overlay.
+// It is an alias for Temperature and kept for backwards compatibility.
+type OverlayInputTemperature = Temperature
+
+// Setting contains the heating, hot water or air conditioning settings of a zone
+type Setting struct {
+	Type        ZoneType    `json:"type"`
+	Power       Power       `json:"power"`
+	Temperature Temperature `json:"temperature"`
+}
+
+// Termination contains when and how an overlay ends
+type Termination struct {
+	Type                   TerminationType `json:"type"`
+	TypeSkillBasedApp      string          `json:"typeSkillBasedApp"`
+	DurationInSeconds      int             `json:"durationInSeconds"`
+	Expiry                 time.Time       `json:"expiry"`
+	RemainingTimeInSeconds int             `json:"remainingTimeInSeconds"`
+	ProjectedExpiry        time.Time       `json:"projectedExpiry"`
+}
+
+// Overlay is a setting overruling the schedule of a zone
+type Overlay struct {
+	Type        OverlayType `json:"type"`
+	Setting     Setting     `json:"setting"`
+	Termination Termination `json:"termination"`
+}
+
+// Input returns the input to set this overlay again.
+// A timer overlay is set for its remaining time, or for its full duration if the remaining time is unknown.
+func (o Overlay) Input() OverlayInput {
+	oi := OverlayInput{
+		Setting: o.Setting,
+		Termination: OverlayInputTermination{
+			Type: o.Termination.Type,
+		},
+	}
+	if o.Termination.Type == TerminationTypeTimer {
+		oi.Termination.DurationInSeconds = o.Termination.RemainingTimeInSeconds
+		if oi.Termination.DurationInSeconds <= 0 {
+			oi.Termination.DurationInSeconds = o.Termination.DurationInSeconds
+		}
+	}
+	return oi
 }
 
 // OverlayOutput is the output for a successful overlay update
 type OverlayOutput struct {
-	Type    string `json:"type"`
-	Setting struct {
-		Type        string `json:"type"`
-		Power       string `json:"power"`
-		Temperature struct {
-			Celsius    float64 `json:"celsius"`
-			Fahrenheit float64 `json:"fahrenheit"`
-		} `json:"temperature"`
-	} `json:"setting"`
-	Termination struct {
-		Type                   string    `json:"type"`
-		TypeSkillBasedApp      string    `json:"typeSkillBasedApp"`
-		DurationInSeconds      int       `json:"durationInSeconds"`
-		Expiry                 time.Time `json:"expiry"`
-		RemainingTimeInSeconds int       `json:"remainingTimeInSeconds"`
-		ProjectedExpiry        time.Time `json:"projectedExpiry"`
-	} `json:"termination"`
+	Overlay
 }
 
 // PutOverlayInput is the input for PutOverlay
@@ -110,3 +217,70 @@ func (poi *DeleteOverlayInput) body() interface{} {
 
 // DeleteOverlayOutput is the output for DeleteOverlay
 type DeleteOverlayOutput struct{}
+
+// ZoneOverlay is the overlay for a single zone in PostOverlays
+type ZoneOverlay struct {
+	ZoneID  int          `json:"room"`
+	Overlay OverlayInput `json:"overlay"`
+}
+
+// validateZoneOverlays validates the overlay of every zone with validate
+func validateZoneOverlays(overlays []ZoneOverlay, validate func(OverlayInput) error) error {
+	for _, zo := range overlays {
+		err := validate(zo.Overlay)
+		if err != nil {
+			return fmt.Errorf("zone %d: %s", zo.ZoneID, err)
+		}
+	}
+	return nil
+}
+
+// PostOverlaysInput is the input for PostOverlays
+type PostOverlaysInput struct {
+	HomeID   int
+	Overlays []ZoneOverlay
+}
+
+func (poi *PostOverlaysInput) method() string {
+	return http.MethodPost
+}
+
+func (poi *PostOverlaysInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/overlay", poi.HomeID)
+}
+
+func (poi *PostOverlaysInput) body() interface{} {
+	return struct {
+		Overlays []ZoneOverlay `json:"overlays"`
+	}{
+		Overlays: poi.Overlays,
+	}
+}
+
+// PostOverlaysOutput is the output for PostOverlays
+type PostOverlaysOutput struct{}
+
+// DeleteOverlaysInput is the input for DeleteOverlays
+type DeleteOverlaysInput struct {
+	HomeID  int
+	ZoneIDs []int
+}
+
+func (doi *DeleteOverlaysInput) method() string {
+	return http.MethodDelete
+}
+
+func (doi *DeleteOverlaysInput) path() string {
+	rooms := make([]string, 0, len(doi.ZoneIDs))
+	for _, id := range doi.ZoneIDs {
+		rooms = append(rooms, strconv.Itoa(id))
+	}
+	return fmt.Sprintf("/v2/homes/%d/overlay?rooms=%s", doi.HomeID, strings.Join(rooms, ","))
+}
+
+func (doi *DeleteOverlaysInput) body() interface{} {
+	return nil
+}
+
+// DeleteOverlaysOutput is the output for DeleteOverlays
+type DeleteOverlaysOutput struct{}
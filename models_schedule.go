@@ -0,0 +1,193 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// TimetableType is an enum type for the timetable types of a zone schedule
+type TimetableType string
+
+const (
+	// TimetableTypeOneDay uses the same blocks for every day of the week
+	TimetableTypeOneDay TimetableType = "ONE_DAY"
+
+	// TimetableTypeThreeDay uses separate blocks for Monday to Friday, Saturday and Sunday
+	TimetableTypeThreeDay TimetableType = "THREE_DAY"
+
+	// TimetableTypeSevenDay uses separate blocks for every day of the week
+	TimetableTypeSevenDay TimetableType = "SEVEN_DAY"
+)
+
+// IsValid returns true if tt is a known TimetableType.
+func (tt TimetableType) IsValid() bool {
+	switch tt {
+	case TimetableTypeOneDay, TimetableTypeThreeDay, TimetableTypeSevenDay:
+		return true
+	}
+	return false
+}
+
+// Timetable is a timetable of a zone schedule
+type Timetable struct {
+	ID   int           `json:"id"`
+	Type TimetableType `json:"type,omitempty"`
+}
+
+// ScheduleBlock is a block of time in a timetable with the setting for that block
+type ScheduleBlock struct {
+	DayType             string  `json:"dayType"`
+	Start               string  `json:"start"`
+	End                 string  `json:"end"`
+	GeolocationOverride bool    `json:"geolocationOverride"`
+	Setting             Setting `json:"setting"`
+}
+
+// AwayConfiguration contains the setting of a zone used when everyone is away
+type AwayConfiguration struct {
+	Type         ZoneType `json:"type"`
+	AutoAdjust   bool     `json:"autoAdjust"`
+	ComfortLevel int      `json:"comfortLevel"`
+	Setting      *Setting `json:"setting,omitempty"`
+}
+
+// GetActiveTimetableInput is the input for GetActiveTimetable
+type GetActiveTimetableInput struct {
+	HomeID int
+	ZoneID int
+}
+
+func (gati *GetActiveTimetableInput) method() string {
+	return http.MethodGet
+}
+
+func (gati *GetActiveTimetableInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/schedule/activeTimetable", gati.HomeID, gati.ZoneID)
+}
+
+func (gati *GetActiveTimetableInput) body() interface{} {
+	return nil
+}
+
+// GetActiveTimetableOutput is the output for GetActiveTimetable
+type GetActiveTimetableOutput struct {
+	Timetable
+}
+
+// PutActiveTimetableInput is the input for PutActiveTimetable
+type PutActiveTimetableInput struct {
+	HomeID int
+	ZoneID int
+	Timetable
+}
+
+func (pati *PutActiveTimetableInput) method() string {
+	return http.MethodPut
+}
+
+func (pati *PutActiveTimetableInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/schedule/activeTimetable", pati.HomeID, pati.ZoneID)
+}
+
+func (pati *PutActiveTimetableInput) body() interface{} {
+	return pati.Timetable
+}
+
+// PutActiveTimetableOutput is the output for PutActiveTimetable
+type PutActiveTimetableOutput struct {
+	Timetable
+}
+
+// GetScheduleBlocksInput is the input for GetScheduleBlocks
+type GetScheduleBlocksInput struct {
+	HomeID      int
+	ZoneID      int
+	TimetableID int
+}
+
+func (gsbi *GetScheduleBlocksInput) method() string {
+	return http.MethodGet
+}
+
+func (gsbi *GetScheduleBlocksInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/schedule/timetables/%d/blocks", gsbi.HomeID, gsbi.ZoneID, gsbi.TimetableID)
+}
+
+func (gsbi *GetScheduleBlocksInput) body() interface{} {
+	return nil
+}
+
+// GetScheduleBlocksOutput is the output for GetScheduleBlocks
+type GetScheduleBlocksOutput []ScheduleBlock
+
+// PutScheduleBlocksInput is the input for PutScheduleBlocks, it replaces the blocks of a single day type
+type PutScheduleBlocksInput struct {
+	HomeID      int
+	ZoneID      int
+	TimetableID int
+	DayType     string
+	Blocks      []ScheduleBlock
+}
+
+func (psbi *PutScheduleBlocksInput) method() string {
+	return http.MethodPut
+}
+
+func (psbi *PutScheduleBlocksInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/schedule/timetables/%d/blocks/%s", psbi.HomeID, psbi.ZoneID, psbi.TimetableID, psbi.DayType)
+}
+
+func (psbi *PutScheduleBlocksInput) body() interface{} {
+	if psbi.Blocks == nil {
+		return []ScheduleBlock{}
+	}
+	return psbi.Blocks
+}
+
+// PutScheduleBlocksOutput is the output for PutScheduleBlocks
+type PutScheduleBlocksOutput []ScheduleBlock
+
+// GetAwayConfigurationInput is the input for GetAwayConfiguration
+type GetAwayConfigurationInput struct {
+	HomeID int
+	ZoneID int
+}
+
+func (gaci *GetAwayConfigurationInput) method() string {
+	return http.MethodGet
+}
+
+func (gaci *GetAwayConfigurationInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/schedule/awayConfiguration", gaci.HomeID, gaci.ZoneID)
+}
+
+func (gaci *GetAwayConfigurationInput) body() interface{} {
+	return nil
+}
+
+// GetAwayConfigurationOutput is the output for GetAwayConfiguration
+type GetAwayConfigurationOutput struct {
+	AwayConfiguration
+}
+
+// PutAwayConfigurationInput is the input for PutAwayConfiguration
+type PutAwayConfigurationInput struct {
+	HomeID int
+	ZoneID int
+	AwayConfiguration
+}
+
+func (paci *PutAwayConfigurationInput) method() string {
+	return http.MethodPut
+}
+
+func (paci *PutAwayConfigurationInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/schedule/awayConfiguration", paci.HomeID, paci.ZoneID)
+}
+
+func (paci *PutAwayConfigurationInput) body() interface{} {
+	return paci.AwayConfiguration
+}
+
+// PutAwayConfigurationOutput is the output for PutAwayConfiguration
+type PutAwayConfigurationOutput struct{}
@@ -0,0 +1,52 @@
+package tado
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnums_IsValid(t *testing.T) {
+	assert.True(t, TadoModeAway.IsValid())
+	assert.False(t, TadoMode("HOLIDAY").IsValid())
+	assert.True(t, LinkStateOffline.IsValid())
+	assert.False(t, LinkState("").IsValid())
+	assert.True(t, OverlayTypeManual.IsValid())
+	assert.False(t, OverlayType("AUTO").IsValid())
+	assert.True(t, PowerOff.IsValid())
+	assert.False(t, Power("on").IsValid())
+	assert.True(t, ZoneTypeHotWater.IsValid())
+	assert.False(t, ZoneType("FLOOR").IsValid())
+	assert.True(t, BatteryStateLow.IsValid())
+	assert.False(t, BatteryState("EMPTY").IsValid())
+	assert.True(t, StripeTypeOverlayActive.IsValid())
+	assert.False(t, StripeType("UNKNOWN").IsValid())
+	assert.True(t, TerminationTypeTimer.IsValid())
+	assert.False(t, TerminationType("NEVER").IsValid())
+	assert.True(t, CallForHeatMedium.IsValid())
+	assert.False(t, CallForHeat("MAX").IsValid())
+}
+
+func TestEnums_JSON(t *testing.T) {
+	in := `{"tadoMode":"HOLIDAY","setting":{"type":"HEATING","power":"ON","temperature":{"celsius":20}},"overlayType":"","link":{"state":"DEGRADED"}}`
+
+	zs := new(ZoneState)
+	err := json.Unmarshal([]byte(in), zs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// known values are decoded into the constants
+	assert.Equal(t, ZoneTypeHeating, zs.Setting.Type)
+	assert.Equal(t, PowerOn, zs.Setting.Power)
+
+	// unknown values are preserved
+	assert.Equal(t, TadoMode("HOLIDAY"), zs.TadoMode)
+	assert.False(t, zs.TadoMode.IsValid())
+	assert.Equal(t, LinkState("DEGRADED"), zs.Link.State)
+
+	b, err := json.Marshal(zs.Link)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"state":"DEGRADED"}`, string(b))
+}
@@ -3,31 +3,45 @@ package tado
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
+// User is a user with access to one or more homes
 type User struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	ID       string `json:"id"`
-	Homes    []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"homes"`
-	Locale        string `json:"locale"`
-	MobileDevices []struct {
-		Name     string `json:"name"`
-		ID       int    `json:"id"`
-		Settings struct {
-			GeoTrackingEnabled bool `json:"geoTrackingEnabled"`
-		} `json:"settings"`
-		DeviceMetadata struct {
-			Platform  string `json:"platform"`
-			OsVersion string `json:"osVersion"`
-			Model     string `json:"model"`
-			Locale    string `json:"locale"`
-		} `json:"deviceMetadata"`
-	} `json:"mobileDevices"`
+	Name          string         `json:"name"`
+	Email         string         `json:"email"`
+	Username      string         `json:"username"`
+	ID            string         `json:"id"`
+	Homes         []HomeSummary  `json:"homes"`
+	Locale        string         `json:"locale"`
+	MobileDevices []MobileDevice `json:"mobileDevices"`
+}
+
+// HomeSummary is the ID and name of a home a user has access to
+type HomeSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// MobileDevice is a phone or tablet with the Tado app
+type MobileDevice struct {
+	Name           string               `json:"name"`
+	ID             int                  `json:"id"`
+	Settings       MobileDeviceSettings `json:"settings"`
+	DeviceMetadata MobileDeviceMetadata `json:"deviceMetadata"`
+}
+
+// MobileDeviceSettings contains the settings of a mobile device
+type MobileDeviceSettings struct {
+	GeoTrackingEnabled bool `json:"geoTrackingEnabled"`
+}
+
+// MobileDeviceMetadata contains info about a mobile device
+type MobileDeviceMetadata struct {
+	Platform  string `json:"platform"`
+	OsVersion string `json:"osVersion"`
+	Model     string `json:"model"`
+	Locale    string `json:"locale"`
 }
 
 // GetUsersInput is the input for GetUsers
@@ -49,3 +63,24 @@ func (gui *GetUsersInput) body() interface{} {
 
 // GetUsersOutput is the output for GetUsers
 type GetUsersOutput []User
+
+// DeleteUserInput is the input for DeleteUser
+type DeleteUserInput struct {
+	HomeID int
+	UserID string
+}
+
+func (dui *DeleteUserInput) method() string {
+	return http.MethodDelete
+}
+
+func (dui *DeleteUserInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/users/%s", dui.HomeID, url.PathEscape(dui.UserID))
+}
+
+func (dui *DeleteUserInput) body() interface{} {
+	return nil
+}
+
+// DeleteUserOutput is the output for DeleteUser
+type DeleteUserOutput struct{}
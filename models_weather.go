@@ -8,26 +8,16 @@ import (
 
 // Weather is the weather info for a home
 type Weather struct {
-	SolarIntensity struct {
-		Type       string    `json:"type"`
-		Percentage float64   `json:"percentage"`
-		Timestamp  time.Time `json:"timestamp"`
-	} `json:"solarIntensity"`
-	OutsideTemperature struct {
-		Celsius    float64   `json:"celsius"`
-		Fahrenheit float64   `json:"fahrenheit"`
-		Timestamp  time.Time `json:"timestamp"`
-		Type       string    `json:"type"`
-		Precision  struct {
-			Celsius    float64 `json:"celsius"`
-			Fahrenheit float64 `json:"fahrenheit"`
-		} `json:"precision"`
-	} `json:"outsideTemperature"`
-	WeatherState struct {
-		Type      string    `json:"type"`
-		Value     string    `json:"value"`
-		Timestamp time.Time `json:"timestamp"`
-	} `json:"weatherState"`
+	SolarIntensity     PercentageDataPoint  `json:"solarIntensity"`
+	OutsideTemperature TemperatureDataPoint `json:"outsideTemperature"`
+	WeatherState       WeatherState         `json:"weatherState"`
+}
+
+// WeatherState is the state of the weather at a point in time, for example CLOUDY_MOSTLY
+type WeatherState struct {
+	Type      string    `json:"type"`
+	Value     string    `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // GetWeatherInput is the input for GetWeather
@@ -6,44 +6,55 @@ import (
 	"time"
 )
 
+// ZoneType is an enum type for the type of a zone
+type ZoneType string
+
+const (
+	// ZoneTypeHeating is used for heating zones
+	ZoneTypeHeating ZoneType = "HEATING"
+
+	// ZoneTypeHotWater is used for hot water zones
+	ZoneTypeHotWater ZoneType = "HOT_WATER"
+
+	// ZoneTypeAirConditioning is used for air conditioning zones
+	ZoneTypeAirConditioning ZoneType = "AIR_CONDITIONING"
+)
+
+// IsValid returns true if zt is a known ZoneType.
+func (zt ZoneType) IsValid() bool {
+	switch zt {
+	case ZoneTypeHeating, ZoneTypeHotWater, ZoneTypeAirConditioning:
+		return true
+	}
+	return false
+}
+
 // Zone contains info about a single zone
 type Zone struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Type        string    `json:"type"`
-	DateCreated time.Time `json:"dateCreated"`
-	DeviceTypes []string  `json:"deviceTypes"`
-	Devices     []struct {
-		DeviceType       string `json:"deviceType"`
-		SerialNo         string `json:"serialNo"`
-		ShortSerialNo    string `json:"shortSerialNo"`
-		CurrentFwVersion string `json:"currentFwVersion"`
-		ConnectionState  struct {
-			Value     bool      `json:"value"`
-			Timestamp time.Time `json:"timestamp"`
-		} `json:"connectionState"`
-		Characteristics struct {
-			Capabilities []string `json:"capabilities"`
-		} `json:"characteristics"`
-		MountingState struct {
-			Value     string    `json:"value"`
-			Timestamp time.Time `json:"timestamp"`
-		} `json:"mountingState"`
-		BatteryState string   `json:"batteryState"`
-		Duties       []string `json:"duties"`
-	} `json:"devices"`
-	ReportAvailable bool `json:"reportAvailable"`
-	SupportsDazzle  bool `json:"supportsDazzle"`
-	DazzleEnabled   bool `json:"dazzleEnabled"`
-	DazzleMode      struct {
-		Supported bool `json:"supported"`
-		Enabled   bool `json:"enabled"`
-	} `json:"dazzleMode"`
-	OpenWindowDetection struct {
-		Supported        bool `json:"supported"`
-		Enabled          bool `json:"enabled"`
-		TimeoutInSeconds int  `json:"timeoutInSeconds"`
-	} `json:"openWindowDetection"`
+	ID                  int                 `json:"id"`
+	Name                string              `json:"name"`
+	Type                ZoneType            `json:"type"`
+	DateCreated         time.Time           `json:"dateCreated"`
+	DeviceTypes         []string            `json:"deviceTypes"`
+	Devices             []Device            `json:"devices"`
+	ReportAvailable     bool                `json:"reportAvailable"`
+	SupportsDazzle      bool                `json:"supportsDazzle"`
+	DazzleEnabled       bool                `json:"dazzleEnabled"`
+	DazzleMode          DazzleMode          `json:"dazzleMode"`
+	OpenWindowDetection OpenWindowDetection `json:"openWindowDetection"`
+}
+
+// DazzleMode contains the dazzle mode settings of a zone
+type DazzleMode struct {
+	Supported bool `json:"supported"`
+	Enabled   bool `json:"enabled"`
+}
+
+// OpenWindowDetection contains the open window detection settings of a zone
+type OpenWindowDetection struct {
+	Supported        bool `json:"supported"`
+	Enabled          bool `json:"enabled"`
+	TimeoutInSeconds int  `json:"timeoutInSeconds"`
 }
 
 // GetZonesInput is the input for GetZones
@@ -65,3 +76,193 @@ func (gzi *GetZonesInput) body() interface{} {
 
 // GetZonesOutput is the output for GetZones
 type GetZonesOutput []Zone
+
+// PutZoneDetailsInput is the input for PutZoneDetails
+type PutZoneDetailsInput struct {
+	HomeID int
+	ZoneID int
+	Name   string
+}
+
+func (pzdi *PutZoneDetailsInput) method() string {
+	return http.MethodPut
+}
+
+func (pzdi *PutZoneDetailsInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/details", pzdi.HomeID, pzdi.ZoneID)
+}
+
+func (pzdi *PutZoneDetailsInput) body() interface{} {
+	return struct {
+		Name string `json:"name"`
+	}{
+		Name: pzdi.Name,
+	}
+}
+
+// PutZoneDetailsOutput is the output for PutZoneDetails
+type PutZoneDetailsOutput struct{}
+
+// PutDazzleModeInput is the input for PutDazzleMode
+type PutDazzleModeInput struct {
+	HomeID  int
+	ZoneID  int
+	Enabled bool
+}
+
+func (pdmi *PutDazzleModeInput) method() string {
+	return http.MethodPut
+}
+
+func (pdmi *PutDazzleModeInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/dazzle", pdmi.HomeID, pdmi.ZoneID)
+}
+
+func (pdmi *PutDazzleModeInput) body() interface{} {
+	return struct {
+		Enabled bool `json:"enabled"`
+	}{
+		Enabled: pdmi.Enabled,
+	}
+}
+
+// PutDazzleModeOutput is the output for PutDazzleMode
+type PutDazzleModeOutput struct{}
+
+type zoneOrderItem struct {
+	ID int `json:"id"`
+}
+
+// PutZoneOrderInput is the input for PutZoneOrder
+type PutZoneOrderInput struct {
+	HomeID int
+	// ZoneIDs contains all zone IDs of the home in the new order
+	ZoneIDs []int
+}
+
+func (pzoi *PutZoneOrderInput) method() string {
+	return http.MethodPut
+}
+
+func (pzoi *PutZoneOrderInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zoneOrder", pzoi.HomeID)
+}
+
+func (pzoi *PutZoneOrderInput) body() interface{} {
+	order := make([]zoneOrderItem, 0, len(pzoi.ZoneIDs))
+	for _, id := range pzoi.ZoneIDs {
+		order = append(order, zoneOrderItem{ID: id})
+	}
+	return order
+}
+
+// PutZoneOrderOutput is the output for PutZoneOrder
+type PutZoneOrderOutput struct{}
+
+// PostZoneDeviceInput is the input for PostZoneDevice
+type PostZoneDeviceInput struct {
+	HomeID   int
+	ZoneID   int
+	SerialNo string
+}
+
+func (pzdi *PostZoneDeviceInput) method() string {
+	return http.MethodPost
+}
+
+func (pzdi *PostZoneDeviceInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/devices", pzdi.HomeID, pzdi.ZoneID)
+}
+
+func (pzdi *PostZoneDeviceInput) body() interface{} {
+	return struct {
+		SerialNo string `json:"serialNo"`
+	}{
+		SerialNo: pzdi.SerialNo,
+	}
+}
+
+// PostZoneDeviceOutput is the output for PostZoneDevice
+type PostZoneDeviceOutput struct{}
+
+// EarlyStart contains the early start setting of a zone, when enabled a zone starts heating early to reach
+// the scheduled temperature in time
+type EarlyStart struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetEarlyStartInput is the input for GetEarlyStart
+type GetEarlyStartInput struct {
+	HomeID int
+	ZoneID int
+}
+
+func (gesi *GetEarlyStartInput) method() string {
+	return http.MethodGet
+}
+
+func (gesi *GetEarlyStartInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/earlyStart", gesi.HomeID, gesi.ZoneID)
+}
+
+func (gesi *GetEarlyStartInput) body() interface{} {
+	return nil
+}
+
+// GetEarlyStartOutput is the output for GetEarlyStart
+type GetEarlyStartOutput struct {
+	EarlyStart
+}
+
+// PutEarlyStartInput is the input for PutEarlyStart
+type PutEarlyStartInput struct {
+	HomeID int
+	ZoneID int
+	EarlyStart
+}
+
+func (pesi *PutEarlyStartInput) method() string {
+	return http.MethodPut
+}
+
+func (pesi *PutEarlyStartInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/earlyStart", pesi.HomeID, pesi.ZoneID)
+}
+
+func (pesi *PutEarlyStartInput) body() interface{} {
+	return pesi.EarlyStart
+}
+
+// PutEarlyStartOutput is the output for PutEarlyStart
+type PutEarlyStartOutput struct {
+	EarlyStart
+}
+
+// PutOpenWindowDetectionInput is the input for PutOpenWindowDetection
+type PutOpenWindowDetectionInput struct {
+	HomeID           int
+	ZoneID           int
+	Enabled          bool
+	TimeoutInSeconds int
+}
+
+func (powdi *PutOpenWindowDetectionInput) method() string {
+	return http.MethodPut
+}
+
+func (powdi *PutOpenWindowDetectionInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/openWindowDetection", powdi.HomeID, powdi.ZoneID)
+}
+
+func (powdi *PutOpenWindowDetectionInput) body() interface{} {
+	return struct {
+		Enabled          bool `json:"enabled"`
+		TimeoutInSeconds int  `json:"timeoutInSeconds,omitempty"`
+	}{
+		Enabled:          powdi.Enabled,
+		TimeoutInSeconds: powdi.TimeoutInSeconds,
+	}
+}
+
+// PutOpenWindowDetectionOutput is the output for PutOpenWindowDetection
+type PutOpenWindowDetectionOutput struct{}
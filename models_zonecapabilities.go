@@ -0,0 +1,70 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// TemperatureRange contains the temperatures that can be set in a zone, in a single unit
+type TemperatureRange struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step"`
+}
+
+// ZoneCapabilities contains the settings supported by a zone
+type ZoneCapabilities struct {
+	Type              ZoneType `json:"type"`
+	CanSetTemperature bool     `json:"canSetTemperature,omitempty"`
+	Temperatures      struct {
+		Celsius    TemperatureRange `json:"celsius"`
+		Fahrenheit TemperatureRange `json:"fahrenheit"`
+	} `json:"temperatures"`
+}
+
+// Range returns the temperature range of the zone in unit.
+func (zc *ZoneCapabilities) Range(unit TemperatureUnit) TemperatureRange {
+	if unit == TemperatureUnitFahrenheit {
+		return zc.Temperatures.Fahrenheit
+	}
+	return zc.Temperatures.Celsius
+}
+
+// Round rounds t to the step of the zone in unit and limits it to the minimum and maximum of the zone.
+func (zc *ZoneCapabilities) Round(t Temperature, unit TemperatureUnit) Temperature {
+	r := zc.Range(unit)
+	t = t.Round(r.Step, unit)
+	if r.Max > r.Min {
+		v := t.Value(unit)
+		if v < r.Min {
+			return NewTemperature(r.Min, unit)
+		}
+		if v > r.Max {
+			return NewTemperature(r.Max, unit)
+		}
+	}
+	return t
+}
+
+// GetZoneCapabilitiesInput is the input for GetZoneCapabilities
+type GetZoneCapabilitiesInput struct {
+	HomeID int
+	ZoneID int
+}
+
+func (gzci *GetZoneCapabilitiesInput) method() string {
+	return http.MethodGet
+}
+
+func (gzci *GetZoneCapabilitiesInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zones/%d/capabilities", gzci.HomeID, gzci.ZoneID)
+}
+
+func (gzci *GetZoneCapabilitiesInput) body() interface{} {
+	return nil
+}
+
+// GetZoneCapabilitiesOutput is the output for GetZoneCapabilities
+type GetZoneCapabilitiesOutput struct {
+	ZoneCapabilities
+}
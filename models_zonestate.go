@@ -6,82 +6,115 @@ import (
 	"time"
 )
 
+// TadoMode is an enum type for the mode of a home or zone
+type TadoMode string
+
+const (
+	// TadoModeHome is used when someone is at home
+	TadoModeHome TadoMode = "HOME"
+
+	// TadoModeAway is used when everyone is away
+	TadoModeAway TadoMode = "AWAY"
+
+	// TadoModeSleep is used when the home is in sleep mode
+	TadoModeSleep TadoMode = "SLEEP"
+)
+
+// IsValid returns true if tm is a known TadoMode.
+func (tm TadoMode) IsValid() bool {
+	switch tm {
+	case TadoModeHome, TadoModeAway, TadoModeSleep:
+		return true
+	}
+	return false
+}
+
+// LinkState is an enum type for the connection state of a zone
+type LinkState string
+
+const (
+	// LinkStateOnline is used when the zone is connected
+	LinkStateOnline LinkState = "ONLINE"
+
+	// LinkStateOffline is used when the zone is not connected
+	LinkStateOffline LinkState = "OFFLINE"
+)
+
+// IsValid returns true if ls is a known LinkState.
+func (ls LinkState) IsValid() bool {
+	switch ls {
+	case LinkStateOnline, LinkStateOffline:
+		return true
+	}
+	return false
+}
+
 // ZoneState is the state of a single zone
 type ZoneState struct {
-	TadoMode                       string      `json:"tadoMode"`
-	GeolocationOverride            bool        `json:"geolocationOverride"`
-	GeolocationOverrideDisableTime interface{} `json:"geolocationOverrideDisableTime"` // TODO
-	Preparation                    interface{} `json:"preparation"`                    // TODO
-	Setting                        struct {
-		Type        string `json:"type"`
-		Power       string `json:"power"`
-		Temperature struct {
-			Celsius    float64 `json:"celsius"`
-			Fahrenheit float64 `json:"fahrenheit"`
-		} `json:"temperature"`
-	} `json:"setting"`
-	OverlayType string `json:"overlayType"`
-	Overlay     struct {
-		Type    string `json:"type"`
-		Setting struct {
-			Type        string `json:"type"`
-			Power       string `json:"power"`
-			Temperature struct {
-				Celsius    float64 `json:"celsius"`
-				Fahrenheit float64 `json:"fahrenheit"`
-			} `json:"temperature"`
-		} `json:"setting"`
-		Termination struct {
-			Type                   string    `json:"type"`
-			TypeSkillBasedApp      string    `json:"typeSkillBasedApp"`
-			DurationInSeconds      int       `json:"durationInSeconds"`
-			Expiry                 time.Time `json:"expiry"`
-			RemainingTimeInSeconds int       `json:"remainingTimeInSeconds"`
-			ProjectedExpiry        time.Time `json:"projectedExpiry"`
-		} `json:"termination"`
-	} `json:"overlay"`
-	OpenWindow         interface{} `json:"openWindow"` // TODO
-	NextScheduleChange struct {
-		Start   time.Time `json:"start"`
-		Setting struct {
-			Type        string `json:"type"`
-			Power       string `json:"power"`
-			Temperature struct {
-				Celsius    float64 `json:"celsius"`
-				Fahrenheit float64 `json:"fahrenheit"`
-			} `json:"temperature"`
-		} `json:"setting"`
-	} `json:"nextScheduleChange"`
-	NextTimeBlock struct {
-		Start time.Time `json:"start"`
-	} `json:"nextTimeBlock"`
-	Link struct {
-		State string `json:"state"`
-	} `json:"link"`
-	ActivityDataPoints struct {
-		HeatingPower struct {
-			Type       string    `json:"type"`
-			Percentage float64   `json:"percentage"`
-			Timestamp  time.Time `json:"timestamp"`
-		} `json:"heatingPower"`
-	} `json:"activityDataPoints"`
-	SensorDataPoints struct {
-		InsideTemperature struct {
-			Celsius    float64   `json:"celsius"`
-			Fahrenheit float64   `json:"fahrenheit"`
-			Timestamp  time.Time `json:"timestamp"`
-			Type       string    `json:"type"`
-			Precision  struct {
-				Celsius    float64 `json:"celsius"`
-				Fahrenheit float64 `json:"fahrenheit"`
-			} `json:"precision"`
-		} `json:"insideTemperature"`
-		Humidity struct {
-			Type       string    `json:"type"`
-			Percentage float64   `json:"percentage"`
-			Timestamp  time.Time `json:"timestamp"`
-		} `json:"humidity"`
-	} `json:"sensorDataPoints"`
+	TadoMode                       TadoMode           `json:"tadoMode"`
+	GeolocationOverride            bool               `json:"geolocationOverride"`
+	GeolocationOverrideDisableTime interface{}        `json:"geolocationOverrideDisableTime"` // TODO
+	Preparation                    interface{}        `json:"preparation"`                    // TODO
+	Setting                        Setting            `json:"setting"`
+	OverlayType                    OverlayType        `json:"overlayType"`
+	Overlay                        Overlay            `json:"overlay"`
+	OpenWindowDetected             bool               `json:"openWindowDetected,omitempty"`
+	OpenWindow                     *OpenWindow        `json:"openWindow"`
+	NextScheduleChange             ScheduleChange     `json:"nextScheduleChange"`
+	NextTimeBlock                  TimeBlock          `json:"nextTimeBlock"`
+	Link                           Link               `json:"link"`
+	ActivityDataPoints             ActivityDataPoints `json:"activityDataPoints"`
+	SensorDataPoints               SensorDataPoints   `json:"sensorDataPoints"`
+}
+
+// OpenWindow contains the details of an open window detected in a zone
+type OpenWindow struct {
+	DetectedTime           time.Time `json:"detectedTime"`
+	DurationInSeconds      int       `json:"durationInSeconds"`
+	Expiry                 time.Time `json:"expiry"`
+	RemainingTimeInSeconds int       `json:"remainingTimeInSeconds"`
+}
+
+// ScheduleChange is a change of setting planned by the schedule of a zone
+type ScheduleChange struct {
+	Start   time.Time `json:"start"`
+	Setting Setting   `json:"setting"`
+}
+
+// TimeBlock is a block of the schedule of a zone
+type TimeBlock struct {
+	Start time.Time `json:"start"`
+}
+
+// Link is the connection state of a zone
+type Link struct {
+	State LinkState `json:"state"`
+}
+
+// PercentageDataPoint is a percentage measured at a point in time
+type PercentageDataPoint struct {
+	Type       string    `json:"type"`
+	Percentage float64   `json:"percentage"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// TemperatureDataPoint is a temperature measured at a point in time
+type TemperatureDataPoint struct {
+	Temperature
+	Timestamp time.Time   `json:"timestamp"`
+	Type      string      `json:"type"`
+	Precision Temperature `json:"precision"`
+}
+
+// ActivityDataPoints contains the latest activity of a zone
+type ActivityDataPoints struct {
+	HeatingPower PercentageDataPoint `json:"heatingPower"`
+}
+
+// SensorDataPoints contains the latest sensor measurements of a zone
+type SensorDataPoints struct {
+	InsideTemperature TemperatureDataPoint `json:"insideTemperature"`
+	Humidity          PercentageDataPoint  `json:"humidity"`
 }
 
 // GetZoneStateInput is the input for GetZoneState
@@ -106,3 +139,28 @@ func (gzsi *GetZoneStateInput) body() interface{} {
 type GetZoneStateOutput struct {
 	ZoneState
 }
+
+// GetZoneStatesInput is the input for GetZoneStates
+type GetZoneStatesInput struct {
+	HomeID int
+}
+
+func (gzsi *GetZoneStatesInput) method() string {
+	return http.MethodGet
+}
+
+func (gzsi *GetZoneStatesInput) path() string {
+	return fmt.Sprintf("/v2/homes/%d/zoneStates", gzsi.HomeID)
+}
+
+func (gzsi *GetZoneStatesInput) body() interface{} {
+	return nil
+}
+
+// GetZoneStatesOutput is the output for GetZoneStates, it contains the state of every zone keyed by zone ID
+type GetZoneStatesOutput map[int]ZoneState
+
+// zoneStatesResponse is the JSON response returned by Tado for GetZoneStates
+type zoneStatesResponse struct {
+	ZoneStates map[int]ZoneState `json:"zoneStates"`
+}
@@ -0,0 +1,127 @@
+package tado
+
+import (
+	"fmt"
+	"time"
+)
+
+// OverlayBuilder builds the input for PutOverlay, use NewOverlay to create one.
+// By default the overlay turns the heating on and lasts until it is removed.
+//
+// For example to heat a zone to 21.5°C for 90 minutes:
+//
+//	in, err := tado.NewOverlay(homeID, zoneID).Heating(21.5).For(90 * time.Minute).Build()
+type OverlayBuilder struct {
+	homeID, zoneID int
+	setting        Setting
+	termination    OverlayInputTermination
+	err            error
+}
+
+// NewOverlay returns a new OverlayBuilder for a zone in a home.
+func NewOverlay(homeID, zoneID int) *OverlayBuilder {
+	return &OverlayBuilder{
+		homeID: homeID,
+		zoneID: zoneID,
+		setting: Setting{
+			Type:  ZoneTypeHeating,
+			Power: PowerOn,
+		},
+		termination: OverlayInputTermination{
+			Type: TerminationTypeManual,
+		},
+	}
+}
+
+// Heating turns the heating on at a temperature in degrees Celsius.
+func (ob *OverlayBuilder) Heating(celsius float64) *OverlayBuilder {
+	return ob.HeatingTemperature(Temperature{Celsius: celsius})
+}
+
+// HeatingTemperature turns the heating on at temperature t.
+func (ob *OverlayBuilder) HeatingTemperature(t Temperature) *OverlayBuilder {
+	ob.setting = Setting{
+		Type:        ZoneTypeHeating,
+		Power:       PowerOn,
+		Temperature: t,
+	}
+	return ob
+}
+
+// HotWater turns the hot water on or off.
+func (ob *OverlayBuilder) HotWater(on bool) *OverlayBuilder {
+	ob.setting = Setting{
+		Type:  ZoneTypeHotWater,
+		Power: PowerOff,
+	}
+	if on {
+		ob.setting.Power = PowerOn
+	}
+	return ob
+}
+
+// Off turns the heating, or the hot water when HotWater was used, off.
+func (ob *OverlayBuilder) Off() *OverlayBuilder {
+	ob.setting.Power = PowerOff
+	ob.setting.Temperature = Temperature{}
+	return ob
+}
+
+// For ends the overlay after duration d, it is rounded down to whole seconds.
+func (ob *OverlayBuilder) For(d time.Duration) *OverlayBuilder {
+	ob.err = nil
+	if d < time.Second {
+		ob.err = fmt.Errorf("invalid overlay: duration %s is shorter than one second", d)
+	}
+	ob.termination = OverlayInputTermination{
+		Type:              TerminationTypeTimer,
+		DurationInSeconds: int(d / time.Second),
+	}
+	return ob
+}
+
+// UntilNextBlock ends the overlay at the start of the next block of the schedule.
+func (ob *OverlayBuilder) UntilNextBlock() *OverlayBuilder {
+	return ob.terminate(TerminationTypeNextTimeBlock)
+}
+
+// UntilTadoMode ends the overlay when Tado changes mode, for example when everyone leaves home.
+func (ob *OverlayBuilder) UntilTadoMode() *OverlayBuilder {
+	return ob.terminate(TerminationTypeTadoMode)
+}
+
+// Manual keeps the overlay until it is removed, this is the default.
+func (ob *OverlayBuilder) Manual() *OverlayBuilder {
+	return ob.terminate(TerminationTypeManual)
+}
+
+func (ob *OverlayBuilder) terminate(tt TerminationType) *OverlayBuilder {
+	ob.err = nil
+	ob.termination = OverlayInputTermination{
+		Type: tt,
+	}
+	return ob
+}
+
+// Build validates the overlay and returns the input for PutOverlay.
+func (ob *OverlayBuilder) Build() (*PutOverlayInput, error) {
+	if ob.err != nil {
+		return nil, ob.err
+	}
+	if ob.homeID <= 0 || ob.zoneID <= 0 {
+		return nil, fmt.Errorf("invalid overlay: home ID %d and zone ID %d must be positive", ob.homeID, ob.zoneID)
+	}
+	in := &PutOverlayInput{
+		HomeID: ob.homeID,
+		ZoneID: ob.zoneID,
+		OverlayInput: OverlayInput{
+			Setting:     ob.setting,
+			Termination: ob.termination,
+		},
+	}
+	err := in.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return in, nil
+}
@@ -0,0 +1,117 @@
+package tado
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlayBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *OverlayBuilder
+		json    string
+		err     string
+	}{
+		{
+			name:    "heating for 90 minutes",
+			builder: NewOverlay(1, 2).Heating(21.5).For(90 * time.Minute),
+			json:    `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":21.5}},"termination":{"type":"TIMER","durationInSeconds":5400}}`,
+		},
+		{
+			name:    "heating off until next block",
+			builder: NewOverlay(1, 2).Off().UntilNextBlock(),
+			json:    `{"setting":{"type":"HEATING","power":"OFF","temperature":{}},"termination":{"type":"NEXT_TIME_BLOCK"}}`,
+		},
+		{
+			name:    "hot water on manually",
+			builder: NewOverlay(1, 3).HotWater(true).Manual(),
+			json:    `{"setting":{"type":"HOT_WATER","power":"ON","temperature":{}},"termination":{"type":"MANUAL"}}`,
+		},
+		{
+			name:    "hot water off until tado mode changes",
+			builder: NewOverlay(1, 3).HotWater(true).Off().UntilTadoMode(),
+			json:    `{"setting":{"type":"HOT_WATER","power":"OFF","temperature":{}},"termination":{"type":"TADO_MODE"}}`,
+		},
+		{
+			name:    "heating without temperature",
+			builder: NewOverlay(1, 2),
+			err:     "invalid overlay: HEATING with power ON requires a temperature",
+		},
+		{
+			name:    "duration too short",
+			builder: NewOverlay(1, 2).Heating(20).For(time.Millisecond),
+			err:     "invalid overlay: duration 1ms is shorter than one second",
+		},
+		{
+			name:    "short duration replaced by manual",
+			builder: NewOverlay(1, 2).Heating(20).For(time.Millisecond).Manual(),
+			json:    `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":20}},"termination":{"type":"MANUAL"}}`,
+		},
+		{
+			name:    "missing zone",
+			builder: NewOverlay(1, 0).Heating(20),
+			err:     "invalid overlay: home ID 1 and zone ID 0 must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := tc.builder.Build()
+			if tc.err != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.err, err.Error())
+				}
+				assert.Nil(t, in)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			b, err := json.Marshal(in.body())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.json, string(b))
+		})
+	}
+}
+
+func TestOverlayInput_Validate(t *testing.T) {
+	oi := OverlayInput{
+		Setting: Setting{
+			Type:        ZoneTypeHeating,
+			Power:       PowerOff,
+			Temperature: Temperature{Celsius: 20},
+		},
+		Termination: OverlayInputTermination{
+			Type: TerminationTypeManual,
+		},
+	}
+	err := oi.Validate()
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid overlay: temperature set while power is OFF", err.Error())
+	}
+
+	oi.Setting.Power = PowerOn
+	oi.Termination.Type = TerminationTypeTimer
+	err = oi.Validate()
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid overlay: termination type TIMER requires a duration", err.Error())
+	}
+
+	oi.Termination.DurationInSeconds = 60
+	assert.NoError(t, oi.Validate())
+
+	oi.Termination.Type = TerminationTypeManual
+	err = oi.Validate()
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid overlay: duration is only allowed with termination type TIMER", err.Error())
+	}
+
+	oi.Setting.Type = "FLOOR"
+	err = oi.Validate()
+	if assert.Error(t, err) {
+		assert.Equal(t, `invalid overlay: unknown setting type "FLOOR"`, err.Error())
+	}
+}
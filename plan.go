@@ -0,0 +1,75 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Change is a single difference between the current and the wanted configuration of a home
+type Change struct {
+	// Target is what the change applies to, for example home 12345, zone 1 "Living Room" or device VA0123456789.
+	Target string
+	// Setting is the name of the changed setting, for example name or early start.
+	Setting string
+	// From and To describe the current and the wanted value.
+	From, To string
+
+	apply func(c *Client) error
+}
+
+func (ch Change) String() string {
+	return fmt.Sprintf("%s: %s: %s -> %s", ch.Target, ch.Setting, ch.From, ch.To)
+}
+
+// Plan contains the changes needed to bring a home to a wanted configuration, use Client.ApplyPlan to apply them.
+type Plan struct {
+	HomeID  int
+	Changes []Change
+	// Skipped describes the parts of the wanted configuration that can not be applied, for example zones
+	// that do not exist.
+	Skipped []string
+}
+
+// Empty returns true if the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns a human readable diff of the plan, with one line per change.
+func (p *Plan) String() string {
+	sb := new(strings.Builder)
+	fmt.Fprintf(sb, "home %d: %d change(s)\n", p.HomeID, len(p.Changes))
+	for _, ch := range p.Changes {
+		fmt.Fprintf(sb, "~ %s\n", ch)
+	}
+	for _, s := range p.Skipped {
+		fmt.Fprintf(sb, "! skipped %s\n", s)
+	}
+	return sb.String()
+}
+
+func (p *Plan) add(target, setting, from, to string, apply func(c *Client) error) {
+	p.Changes = append(p.Changes, Change{
+		Target:  target,
+		Setting: setting,
+		From:    from,
+		To:      to,
+		apply:   apply,
+	})
+}
+
+// ApplyPlan applies the changes of a plan in order.
+// It stops at the first change that fails, or when ctx is done, and returns the number of applied changes.
+func (c *Client) ApplyPlan(ctx context.Context, p *Plan) (int, error) {
+	for i, ch := range p.Changes {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		err := ch.apply(c)
+		if err != nil {
+			return i, fmt.Errorf("error applying %s: %s", ch, err)
+		}
+	}
+	return len(p.Changes), nil
+}
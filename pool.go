@@ -0,0 +1,294 @@
+package tado
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+)
+
+// DefaultUnknownHomeTTL is the time a home that was not found is remembered by a Pool
+const DefaultUnknownHomeTTL = 5 * time.Minute
+
+// Pool holds clients for many Tado accounts, which share one http.Client and TokenStore.
+// Requests for a home are routed to the account that has access to it.
+type Pool struct {
+	// UnknownHomeTTL is the time a home that was not found is remembered, lookups of that home then fail
+	// without discovering the homes of every account again. It defaults to DefaultUnknownHomeTTL.
+	UnknownHomeTTL time.Duration
+
+	httpClient     *http.Client
+	tokens         TokenStore
+	requestsPerDay int
+
+	mutex   sync.Mutex
+	clients map[string]*Client
+	homes   map[int]string
+	unknown map[int]unknownHome
+	// added counts the accounts added to the pool, homes are only remembered as unknown when no account
+	// was added since the discovery that did not find them started
+	added int
+
+	// discovery is the Discover in progress, discovered counts the finished discoveries and lastDiscovery
+	// is the last one that finished
+	discovery     *discovery
+	discovered    int
+	lastDiscovery *discovery
+}
+
+// unknownHome is a home that was not found in any account
+type unknownHome struct {
+	expires time.Time
+	err     error
+}
+
+// discovery is a Discover in progress, shared by all callers
+type discovery struct {
+	done  chan struct{}
+	added int // the number of accounts added to the pool when the discovery started
+	err   error
+}
+
+// NewPool returns an empty pool. When httpClient is nil http.DefaultClient is used, when tokens is nil the
+// tokens are kept in memory. Every account is limited to requestsPerDay requests, 0 means no limit.
+func NewPool(httpClient *http.Client, tokens TokenStore, requestsPerDay int) *Pool {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if tokens == nil {
+		tokens = NewMemoryTokenStore()
+	}
+	return &Pool{
+		UnknownHomeTTL: DefaultUnknownHomeTTL,
+		httpClient:     httpClient,
+		tokens:         tokens,
+		requestsPerDay: requestsPerDay,
+		clients:        make(map[string]*Client),
+		homes:          make(map[int]string),
+		unknown:        make(map[int]unknownHome),
+	}
+}
+
+// Add adds the account of username to the pool and returns its client, the account is keyed by username.
+// An existing account with the same username is replaced and the background refresh of its client is stopped.
+func (p *Pool) Add(username, password string) *Client {
+	c := NewClient(username, password)
+	c.HTTPClient = p.httpClient
+	if ac, ok := c.authClient.(*tadoauth.Client); ok {
+		ac.HTTPClient = p.httpClient
+	}
+	c.SetTokenStore(p.tokens, username)
+	c.SetRequestBudget(p.requestsPerDay)
+
+	p.mutex.Lock()
+	old := p.clients[username]
+	p.clients[username] = c
+	p.forgetHomes(username)
+	// the new account may have access to homes that were not found before
+	p.unknown = make(map[int]unknownHome)
+	p.added++
+	p.mutex.Unlock()
+
+	if old != nil {
+		old.StopBackgroundRefresh()
+	}
+	return c
+}
+
+// Remove removes an account from the pool and stops the background refresh of its client.
+func (p *Pool) Remove(account string) {
+	p.mutex.Lock()
+	old := p.clients[account]
+	delete(p.clients, account)
+	p.forgetHomes(account)
+	p.mutex.Unlock()
+
+	if old != nil {
+		old.StopBackgroundRefresh()
+	}
+}
+
+// forgetHomes removes the homes of account from the routing table, the mutex must be held
+func (p *Pool) forgetHomes(account string) {
+	for id, a := range p.homes {
+		if a == account {
+			delete(p.homes, id)
+		}
+	}
+}
+
+// Accounts returns the accounts in the pool, sorted by name.
+func (p *Pool) Accounts() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	accounts := make([]string, 0, len(p.clients))
+	for a := range p.clients {
+		accounts = append(accounts, a)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// Client returns the client of account, or nil if the account is not in the pool.
+func (p *Pool) Client(account string) *Client {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.clients[account]
+}
+
+// ClientForHome returns the client of the account that has access to homeID.
+// Homes are discovered using GetMe the first time an unknown home is requested. A home that is still not
+// found is remembered for UnknownHomeTTL, during which lookups fail with the same error without discovering again.
+func (p *Pool) ClientForHome(homeID int) (*Client, error) {
+	c, discovered, err := p.lookupHome(homeID)
+	if c != nil || err != nil {
+		return c, err
+	}
+	// a discovery that finished after the lookup is not repeated
+	added, err := p.discoverAfter(discovered)
+	if c, _, _ := p.lookupHome(homeID); c != nil {
+		return c, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("home %d not found: %s", homeID, err)
+	} else {
+		err = fmt.Errorf("home %d not found in any account", homeID)
+	}
+
+	ttl := p.UnknownHomeTTL
+	if ttl == 0 {
+		ttl = DefaultUnknownHomeTTL
+	}
+	p.mutex.Lock()
+	// an account added after the discovery started may have access to the home
+	if p.added == added {
+		p.unknown[homeID] = unknownHome{expires: time.Now().Add(ttl), err: err}
+	}
+	p.mutex.Unlock()
+	return nil, err
+}
+
+// lookupHome returns the client for homeID from the routing table and the number of finished discoveries.
+// The client is nil if the home is not known, the error is set if the home was not found recently.
+func (p *Pool) lookupHome(homeID int) (*Client, int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if account, ok := p.homes[homeID]; ok {
+		if c := p.clients[account]; c != nil {
+			return c, p.discovered, nil
+		}
+	}
+	if uh, ok := p.unknown[homeID]; ok {
+		if time.Now().Before(uh.expires) {
+			return nil, p.discovered, uh.err
+		}
+		delete(p.unknown, homeID)
+	}
+	return nil, p.discovered, nil
+}
+
+// Home returns the handle of homeID using the client of the account that has access to it.
+func (p *Pool) Home(homeID int) (*HomeHandle, error) {
+	c, err := p.ClientForHome(homeID)
+	if err != nil {
+		return nil, err
+	}
+	return c.Home(homeID), nil
+}
+
+// Discover rebuilds the routing table from the homes of every account. When a home is shared by several
+// accounts, the first account in sorted order is used. Accounts that fail keep the homes they had before,
+// and their errors are returned together. Concurrent calls share one discovery.
+func (p *Pool) Discover() error {
+	p.mutex.Lock()
+	discovered := p.discovered
+	p.mutex.Unlock()
+	_, err := p.discoverAfter(discovered)
+	return err
+}
+
+// discoverAfter joins the discovery in progress or starts a new one, unless more than discovered discoveries
+// have finished, then the last one is used. It returns the number of accounts added to the pool when the
+// used discovery started and its error.
+func (p *Pool) discoverAfter(discovered int) (int, error) {
+	p.mutex.Lock()
+	if p.discovered > discovered {
+		d := p.lastDiscovery
+		p.mutex.Unlock()
+		return d.added, d.err
+	}
+	if d := p.discovery; d != nil {
+		p.mutex.Unlock()
+		<-d.done
+		return d.added, d.err
+	}
+	d := &discovery{done: make(chan struct{}), added: p.added}
+	p.discovery = d
+	p.mutex.Unlock()
+
+	d.err = p.discover()
+
+	p.mutex.Lock()
+	p.discovery = nil
+	p.discovered++
+	p.lastDiscovery = d
+	p.mutex.Unlock()
+	close(d.done)
+	return d.added, d.err
+}
+
+// discover rebuilds the routing table, see Discover
+func (p *Pool) discover() error {
+	accounts := p.Accounts()
+
+	p.mutex.Lock()
+	previous := make(map[string][]int)
+	for id, account := range p.homes {
+		previous[account] = append(previous[account], id)
+	}
+	p.mutex.Unlock()
+
+	homes := make(map[int]string)
+	var errs []string
+	for _, account := range accounts {
+		c := p.Client(account)
+		if c == nil {
+			continue
+		}
+		var ids []int
+		me, err := c.GetMe()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("account %s: %s", account, err))
+			ids = previous[account]
+		} else {
+			for _, h := range me.Homes {
+				ids = append(ids, h.ID)
+			}
+		}
+		for _, id := range ids {
+			if _, ok := homes[id]; !ok {
+				homes[id] = account
+			}
+		}
+	}
+
+	p.mutex.Lock()
+	for id, account := range homes {
+		// accounts removed during discovery are left out
+		if _, ok := p.clients[account]; !ok {
+			delete(homes, id)
+		}
+	}
+	p.homes = homes
+	p.unknown = make(map[int]unknownHome)
+	p.mutex.Unlock()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("discovering homes failed for %d account(s): %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+	"github.com/stretchr/testify/assert"
+)
+
+// accountAuthClient returns a token named after the user
+type accountAuthClient struct {
+	calls int32
+}
+
+func (aa *accountAuthClient) GetToken(username, password string) (*tadoauth.TokenResponse, error) {
+	atomic.AddInt32(&aa.calls, 1)
+	return &tadoauth.TokenResponse{AccessToken: "token-" + username, RefreshToken: "refresh-" + username, ExpiresIn: 600}, nil
+}
+
+func (aa *accountAuthClient) RefreshToken(refreshToken string) (*tadoauth.TokenResponse, error) {
+	return aa.GetToken(strings.TrimPrefix(refreshToken, "refresh-"), "")
+}
+
+func TestPool(t *testing.T) {
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer token-")
+		requests = append(requests, account+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/v2/me" && account == "alice":
+			_, _ = fmt.Fprint(w, `{"homes": [{"id": 1, "name": "A1"}, {"id": 2, "name": "A2"}]}`)
+		case r.URL.Path == "/v2/me" && account == "bob":
+			_, _ = fmt.Fprint(w, `{"homes": [{"id": 2, "name": "A2"}, {"id": 3, "name": "B3"}]}`)
+		case r.URL.Path == "/v2/me":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			_, _ = fmt.Fprint(w, `{"id": 3}`)
+		}
+	}))
+	defer s.Close()
+
+	httpClient := &http.Client{}
+	tokens := NewMemoryTokenStore()
+	pool := NewPool(httpClient, tokens, 0)
+	auth := new(accountAuthClient)
+	for _, account := range []string{"bob", "alice", "carol"} {
+		c := pool.Add(account, "pw")
+		assert.Same(t, httpClient, c.HTTPClient)
+		c.authClient = auth
+		c.baseURL = s.URL
+	}
+	assert.Equal(t, []string{"alice", "bob", "carol"}, pool.Accounts())
+	assert.Nil(t, pool.Client("dave"))
+
+	// homes are discovered on the first lookup
+	c, err := pool.ClientForHome(3)
+	if assert.NoError(t, err) {
+		assert.Same(t, pool.Client("bob"), c)
+	}
+	c, err = pool.ClientForHome(2)
+	if assert.NoError(t, err) {
+		assert.Same(t, pool.Client("alice"), c, "shared homes use the first account")
+	}
+	assert.Equal(t, []string{"alice /v2/me", "bob /v2/me", "carol /v2/me"}, requests)
+
+	home, err := pool.Home(3)
+	if assert.NoError(t, err) {
+		_, err = home.Home()
+		assert.NoError(t, err)
+		assert.Equal(t, "bob /v2/homes/3", requests[len(requests)-1])
+	}
+
+	// unknown homes trigger a new discovery, the failed account is reported
+	_, err = pool.ClientForHome(9)
+	if assert.Error(t, err) {
+		assert.Equal(t, "home 9 not found: discovering homes failed for 1 account(s): account carol: error: HTTP status 401: ", err.Error())
+	}
+
+	// tokens are stored per account and shared by new clients of the same account
+	st, err := tokens.Load("alice")
+	if assert.NoError(t, err) && assert.NotNil(t, st) {
+		assert.Equal(t, "token-alice", st.AccessToken)
+	}
+	calls := atomic.LoadInt32(&auth.calls)
+	c = pool.Add("alice", "pw")
+	c.authClient = auth
+	c.baseURL = s.URL
+	_, err = c.GetMe()
+	assert.NoError(t, err)
+	assert.Equal(t, calls, atomic.LoadInt32(&auth.calls), "stored token must be reused")
+
+	pool.Remove("bob")
+	_, err = pool.ClientForHome(3)
+	assert.Error(t, err)
+}
+
+func TestPool_UnknownHomes(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	bobFails := false
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer token-")
+		mu.Lock()
+		requests[account]++
+		fail := bobFails && account == "bob"
+		mu.Unlock()
+		switch {
+		case fail:
+			<-release
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case account == "alice":
+			_, _ = fmt.Fprint(w, `{"homes": [{"id": 1}]}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"homes": [{"id": 5}]}`)
+		}
+	}))
+	defer s.Close()
+
+	pool := NewPool(nil, nil, 0)
+	auth := new(accountAuthClient)
+	for _, account := range []string{"alice", "bob"} {
+		c := pool.Add(account, "pw")
+		c.authClient = auth
+		c.baseURL = s.URL
+	}
+	count := func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		r := make(map[string]int)
+		for k, v := range requests {
+			r[k] = v
+		}
+		return r
+	}
+
+	_, err := pool.ClientForHome(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"alice": 1, "bob": 1}, count())
+
+	// concurrent lookups of an unknown home share one discovery
+	mu.Lock()
+	bobFails = true
+	mu.Unlock()
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := pool.ClientForHome(9)
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for i := 0; i < 10; i++ {
+		if err := <-errs; assert.Error(t, err) {
+			assert.Equal(t, "home 9 not found: discovering homes failed for 1 account(s): account bob: error: HTTP status 503: ", err.Error())
+		}
+	}
+	assert.Equal(t, map[string]int{"alice": 2, "bob": 2}, count())
+
+	// the failed account keeps its homes
+	c, err := pool.ClientForHome(5)
+	if assert.NoError(t, err) {
+		assert.Same(t, pool.Client("bob"), c)
+	}
+
+	// the unknown home is remembered
+	_, err = pool.ClientForHome(9)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"alice": 2, "bob": 2}, count())
+
+	// and looked up again when it expired
+	pool.mutex.Lock()
+	uh := pool.unknown[9]
+	uh.expires = time.Now().Add(-time.Second)
+	pool.unknown[9] = uh
+	pool.mutex.Unlock()
+	_, err = pool.ClientForHome(9)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"alice": 3, "bob": 3}, count())
+}
+
+func TestPool_AddDuringDiscovery(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer token-") {
+		case "alice":
+			close(started)
+			<-release
+			_, _ = fmt.Fprint(w, `{"homes": [{"id": 1}]}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"homes": [{"id": 7}]}`)
+		}
+	}))
+	defer s.Close()
+
+	pool := NewPool(nil, nil, 0)
+	auth := new(accountAuthClient)
+	c := pool.Add("alice", "pw")
+	c.authClient = auth
+	c.baseURL = s.URL
+
+	errs := make(chan error)
+	go func() {
+		_, err := pool.ClientForHome(7)
+		errs <- err
+	}()
+
+	// an account added while the discovery is running is not part of it, but the home is not remembered
+	// as unknown because the new account may have access to it
+	<-started
+	c = pool.Add("bob", "pw")
+	c.authClient = auth
+	c.baseURL = s.URL
+	close(release)
+	assert.Error(t, <-errs)
+
+	c, err := pool.ClientForHome(7)
+	if assert.NoError(t, err) {
+		assert.Same(t, pool.Client("bob"), c)
+	}
+}
+
+func TestPool_AddStopsBackgroundRefresh(t *testing.T) {
+	defer func(d time.Duration) { backgroundRefreshRetry = d }(backgroundRefreshRetry)
+	backgroundRefreshRetry = 10 * time.Millisecond
+
+	pool := NewPool(nil, nil, 0)
+	old := pool.Add("alice", "pw")
+	auth := &countingAuthClient{expiresIn: 0}
+	old.authClient = auth
+	old.StartBackgroundRefresh(context.Background(), time.Minute)
+	for auth.Calls() < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// replacing the account stops the refresh of the old client
+	pool.Add("alice", "pw2")
+	time.Sleep(50 * time.Millisecond)
+	calls := auth.Calls()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, calls, auth.Calls())
+}
+
+func TestClient_SetRequestBudget(t *testing.T) {
+	client, server := setupTestClientAndServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	defer server.Close()
+
+	now := time.Now()
+	client.SetRequestBudget(2)
+	client.budget.now = func() time.Time { return now }
+	client.budget.last = now
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetMe()
+		assert.NoError(t, err)
+	}
+	_, err := client.GetMe()
+	if assert.Error(t, err) && assert.IsType(t, new(RateLimitError), err) {
+		assert.Equal(t, "request budget of 2 requests per day exceeded, retry after 12h0m0s", err.Error())
+	}
+
+	// the budget is refilled over time
+	now = now.Add(12 * time.Hour)
+	_, err = client.GetMe()
+	assert.NoError(t, err)
+
+	client.SetRequestBudget(0)
+	_, err = client.GetMe()
+	assert.NoError(t, err)
+}
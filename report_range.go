@@ -0,0 +1,267 @@
+package tado
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReportRangeConcurrency is the number of concurrent requests used by GetReportRange when no concurrency is set
+const DefaultReportRangeConcurrency = 4
+
+// MaxReportRangeDays is the maximum number of days GetReportRange returns, every day uses one request
+const MaxReportRangeDays = 366
+
+// GetReportRangeInput is the input for GetReportRange
+type GetReportRangeInput struct {
+	HomeID int
+	ZoneID int
+	// From and To are the first and last day of the range, only their dates are used
+	From time.Time
+	To   time.Time
+	// Location is the time zone of the home, it is loaded from Home.DateTimeZone when it is nil
+	Location *time.Location
+	// Concurrency is the maximum number of concurrent requests, it defaults to DefaultReportRangeConcurrency.
+	Concurrency int
+}
+
+// GetReportRangeOutput is the output for GetReportRange
+type GetReportRangeOutput struct {
+	// DayReport contains the time series of all days merged into continuous time series.
+	// The weather slots are keyed by date and time, for example "2024-03-31 08:00".
+	DayReport
+	// Days contains the report of every day, clipped to the day in the time zone of the home
+	Days     []DayReport
+	Location *time.Location
+}
+
+// GetReportRange returns the day reports for a range of days, merged into continuous time series.
+// Days are determined in the time zone of the home, so days in which daylight saving time starts or ends
+// have 23 or 25 hours. Intervals that span midnight are merged into a single interval.
+// A range can contain at most MaxReportRangeDays days. When a day fails no further days are requested.
+func (c *Client) GetReportRange(in *GetReportRangeInput) (*GetReportRangeOutput, error) {
+	n := reportRangeDays(in.From, in.To)
+	if n < 1 {
+		return nil, fmt.Errorf("invalid report range: %s is after %s", in.From.Format("2006-01-02"), in.To.Format("2006-01-02"))
+	}
+	if n > MaxReportRangeDays {
+		return nil, fmt.Errorf("invalid report range: %d days from %s, at most %d days are allowed", n, in.From.Format("2006-01-02"), MaxReportRangeDays)
+	}
+
+	loc := in.Location
+	if loc == nil {
+		home, err := c.GetHome(&GetHomeInput{HomeID: in.HomeID})
+		if err != nil {
+			return nil, err
+		}
+		loc, err = time.LoadLocation(home.DateTimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("error loading time zone of home %d: %s", in.HomeID, err)
+		}
+	}
+
+	days := reportDays(in.From, in.To, loc)
+
+	concurrency := in.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultReportRangeConcurrency
+	}
+	reports := make([]DayReport, len(days))
+	errs := make([]error, len(days))
+	sem := make(chan struct{}, concurrency)
+	wg := new(sync.WaitGroup)
+	var failed atomic.Bool
+	for i := range days {
+		sem <- struct{}{}
+		if failed.Load() {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			out, err := c.GetDayReport(&GetDayReportInput{HomeID: in.HomeID, ZoneID: in.ZoneID, Date: days[i].From})
+			if err != nil {
+				errs[i] = fmt.Errorf("day %s: %s", days[i].From.Format("2006-01-02"), err)
+				failed.Store(true)
+				return
+			}
+			reports[i] = out.DayReport
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := &GetReportRangeOutput{
+		DayReport: mergeDayReports(reports, days),
+		Days:      make([]DayReport, len(reports)),
+		Location:  loc,
+	}
+	for i := range reports {
+		out.Days[i] = mergeDayReports(reports[i:i+1], days[i:i+1])
+	}
+	return out, nil
+}
+
+// reportRangeDays returns the number of days from the date of from to the date of to
+func reportRangeDays(from, to time.Time) int {
+	y, m, d := from.Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = to.Date()
+	last := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(last.Sub(first)/(24*time.Hour)) + 1
+}
+
+// reportDays returns the intervals of the days from the date of from to the date of to in loc
+func reportDays(from, to time.Time, loc *time.Location) []Interval {
+	y, m, d := from.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	y, m, d = to.Date()
+	last := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	var days []Interval
+	for !day.After(last) {
+		// AddDate keeps midnight in loc, so the interval is 23 or 25 hours on days with a DST change
+		next := day.AddDate(0, 0, 1)
+		days = append(days, Interval{From: day, To: next})
+		day = next
+	}
+	return days
+}
+
+// mergeDayReports merges the reports of consecutive days, every report is clipped to its day
+func mergeDayReports(reports []DayReport, days []Interval) DayReport {
+	r := DayReport{
+		Interval: Interval{From: days[0].From, To: days[len(days)-1].To},
+	}
+	r.HoursInDay = int(r.Interval.Duration().Round(time.Hour) / time.Hour)
+	for _, dr := range reports {
+		if r.ZoneType == "" {
+			r.ZoneType = dr.ZoneType
+		}
+	}
+
+	r.MeasuredData.MeasuringDeviceConnected = mergeIntervalSeries(days, reports, func(dr *DayReport) *IntervalSeries[bool] {
+		return &dr.MeasuredData.MeasuringDeviceConnected
+	})
+	r.Stripes = mergeIntervalSeries(days, reports, func(dr *DayReport) *IntervalSeries[Stripe] { return &dr.Stripes })
+	r.Settings = mergeIntervalSeries(days, reports, func(dr *DayReport) *IntervalSeries[Setting] { return &dr.Settings })
+	r.CallForHeat = mergeIntervalSeries(days, reports, func(dr *DayReport) *IntervalSeries[CallForHeat] { return &dr.CallForHeat })
+	r.Weather.Condition = mergeIntervalSeries(days, reports, func(dr *DayReport) *IntervalSeries[WeatherCondition] {
+		return &dr.Weather.Condition
+	})
+	r.Weather.Sunny = mergeIntervalSeries(days, reports, func(dr *DayReport) *IntervalSeries[bool] { return &dr.Weather.Sunny })
+
+	r.MeasuredData.InsideTemperature = mergePointSeries(days, reports, func(dr *DayReport) *PointSeries[Temperature] {
+		return &dr.MeasuredData.InsideTemperature
+	})
+	it := &r.MeasuredData.InsideTemperature
+	it.Min, it.Max = Temperature{}, Temperature{}
+	for i, dp := range it.DataPoints {
+		if i == 0 || dp.Value.Celsius < it.Min.Celsius {
+			it.Min = dp.Value
+		}
+		if i == 0 || dp.Value.Celsius > it.Max.Celsius {
+			it.Max = dp.Value
+		}
+	}
+
+	r.MeasuredData.Humidity.PointSeries = mergePointSeries(days, reports, func(dr *DayReport) *PointSeries[float64] {
+		return &dr.MeasuredData.Humidity.PointSeries
+	})
+	h := &r.MeasuredData.Humidity
+	h.Min, h.Max = 0, 0
+	for i, dp := range h.DataPoints {
+		if i == 0 || dp.Value < h.Min {
+			h.Min = dp.Value
+		}
+		if i == 0 || dp.Value > h.Max {
+			h.Max = dp.Value
+		}
+	}
+
+	r.Weather.Slots.Slots = make(map[string]WeatherCondition)
+	for i, dr := range reports {
+		if r.MeasuredData.Humidity.PercentageUnit == "" {
+			r.MeasuredData.Humidity.PercentageUnit = dr.MeasuredData.Humidity.PercentageUnit
+		}
+		if r.Weather.Slots.TimeSeriesType == "" {
+			r.Weather.Slots.TimeSeries = dr.Weather.Slots.TimeSeries
+		}
+		date := days[i].From.Format("2006-01-02")
+		for slot, wc := range dr.Weather.Slots.Slots {
+			r.Weather.Slots.Slots[date+" "+slot] = wc
+		}
+	}
+	return r
+}
+
+// mergeIntervalSeries clips the intervals of every day to that day, and merges adjacent intervals with equal values
+func mergeIntervalSeries[T any](days []Interval, reports []DayReport, series func(dr *DayReport) *IntervalSeries[T]) IntervalSeries[T] {
+	var merged IntervalSeries[T]
+	for i := range reports {
+		s := series(&reports[i])
+		if merged.TimeSeriesType == "" {
+			merged.TimeSeries = s.TimeSeries
+		}
+		for _, di := range s.DataIntervals {
+			di.From, di.To = clipTime(di.From, days[i]), clipTime(di.To, days[i])
+			if !di.To.After(di.From) {
+				continue
+			}
+			n := len(merged.DataIntervals)
+			if n > 0 {
+				last := &merged.DataIntervals[n-1]
+				if !di.From.After(last.To) && reflect.DeepEqual(last.Value, di.Value) {
+					if di.To.After(last.To) {
+						last.To = di.To
+					}
+					continue
+				}
+			}
+			merged.DataIntervals = append(merged.DataIntervals, di)
+		}
+	}
+	return merged
+}
+
+// mergePointSeries concatenates the data points of every day that fall within that day, sorted by time
+func mergePointSeries[T any](days []Interval, reports []DayReport, series func(dr *DayReport) *PointSeries[T]) PointSeries[T] {
+	var merged PointSeries[T]
+	for i := range reports {
+		s := series(&reports[i])
+		if merged.TimeSeriesType == "" {
+			merged.TimeSeries = s.TimeSeries
+		}
+		for _, dp := range s.DataPoints {
+			if dp.Timestamp.Before(days[i].From) || !dp.Timestamp.Before(days[i].To) {
+				continue
+			}
+			merged.DataPoints = append(merged.DataPoints, dp)
+		}
+	}
+	sort.SliceStable(merged.DataPoints, func(i, j int) bool {
+		return merged.DataPoints[i].Timestamp.Before(merged.DataPoints[j].Timestamp)
+	})
+	return merged
+}
+
+// clipTime returns t limited to the interval
+func clipTime(t time.Time, i Interval) time.Time {
+	if t.Before(i.From) {
+		return i.From
+	}
+	if t.After(i.To) {
+		return i.To
+	}
+	return t
+}
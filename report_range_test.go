@@ -0,0 +1,156 @@
+package tado
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	_ "time/tzdata" // ensure the time zone of the test home is available
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDayReport returns a day report for the day starting at midnight, with intervals that extend past midnight
+func testDayReport(midnight time.Time) DayReport {
+	next := midnight.AddDate(0, 0, 1)
+	dr := DayReport{
+		ZoneType:   ZoneTypeHeating,
+		Interval:   Interval{From: midnight, To: next},
+		HoursInDay: int(next.Sub(midnight).Hours()),
+	}
+	dr.CallForHeat.TimeSeriesType = "dataIntervals"
+	dr.CallForHeat.DataIntervals = []DataInterval[CallForHeat]{
+		{From: midnight, To: midnight.Add(6 * time.Hour), Value: CallForHeatHigh},
+		{From: midnight.Add(6 * time.Hour), To: midnight.Add(20 * time.Hour), Value: CallForHeatNone},
+		{From: midnight.Add(20 * time.Hour), To: next.Add(time.Hour), Value: CallForHeatHigh},
+	}
+	for h := 0; h <= 24; h += 6 {
+		ts := time.Date(midnight.Year(), midnight.Month(), midnight.Day(), h, 0, 0, 0, midnight.Location())
+		dr.MeasuredData.InsideTemperature.DataPoints = append(dr.MeasuredData.InsideTemperature.DataPoints,
+			DataPoint[Temperature]{Timestamp: ts, Value: Temperature{Celsius: 18 + float64(h)/6}})
+		dr.MeasuredData.Humidity.DataPoints = append(dr.MeasuredData.Humidity.DataPoints,
+			DataPoint[float64]{Timestamp: ts, Value: 0.5 + float64(h)/100})
+	}
+	dr.Weather.Slots.Slots = map[string]WeatherCondition{"08:00": {State: "SUN", Temperature: Temperature{Celsius: 5}}}
+	return dr
+}
+
+func TestClient_GetReportRange(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inFlight, maxInFlight int32
+	f := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/homes/1" {
+			_, _ = w.Write([]byte(`{"id": 1, "dateTimeZone": "Europe/Amsterdam"}`))
+			return
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		assert.True(t, strings.HasPrefix(r.URL.Path, "/v2/homes/1/zones/2/dayReport"))
+		day, err := time.ParseInLocation("2006-01-02", r.URL.Query().Get("date"), loc)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(testDayReport(day))
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	// the range contains the start of daylight saving time on 31 March
+	out, err := client.GetReportRange(&GetReportRangeInput{
+		HomeID:      1,
+		ZoneID:      2,
+		From:        time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	assert.Equal(t, "Europe/Amsterdam", out.Location.String())
+	assert.Equal(t, ZoneTypeHeating, out.ZoneType)
+	assert.Equal(t, 71, out.HoursInDay)
+	assert.True(t, time.Date(2024, 3, 30, 0, 0, 0, 0, loc).Equal(out.Interval.From))
+	assert.True(t, time.Date(2024, 4, 2, 0, 0, 0, 0, loc).Equal(out.Interval.To))
+
+	if assert.Len(t, out.Days, 3) {
+		assert.Equal(t, 24, out.Days[0].HoursInDay)
+		assert.Equal(t, 23, out.Days[1].HoursInDay)
+		assert.Equal(t, 24, out.Days[2].HoursInDay)
+		// intervals past midnight are clipped to the day
+		last := out.Days[0].CallForHeat.DataIntervals[2]
+		assert.True(t, time.Date(2024, 3, 31, 0, 0, 0, 0, loc).Equal(last.To))
+	}
+
+	// intervals spanning midnight are merged
+	var values []CallForHeat
+	for _, di := range out.CallForHeat.DataIntervals {
+		values = append(values, di.Value)
+	}
+	assert.Equal(t, []CallForHeat{"HIGH", "NONE", "HIGH", "NONE", "HIGH", "NONE", "HIGH"}, values)
+	spanning := out.CallForHeat.DataIntervals[2]
+	assert.True(t, time.Date(2024, 3, 30, 20, 0, 0, 0, loc).Equal(spanning.From))
+	// the test data uses 6 real hours after midnight, which is 07:00 on 31 March because the clock moved forward
+	assert.True(t, time.Date(2024, 3, 31, 7, 0, 0, 0, loc).Equal(spanning.To), spanning.To.In(loc).String())
+	assert.Equal(t, 10*time.Hour, spanning.Duration())
+	assert.True(t, out.Interval.To.Equal(out.CallForHeat.DataIntervals[6].To))
+
+	// data points of the next midnight belong to the next day only
+	assert.Len(t, out.MeasuredData.InsideTemperature.DataPoints, 12)
+	assert.Equal(t, 18.0, out.MeasuredData.InsideTemperature.Min.Celsius)
+	assert.Equal(t, 21.0, out.MeasuredData.InsideTemperature.Max.Celsius)
+	assert.Len(t, out.MeasuredData.Humidity.DataPoints, 12)
+	assert.Equal(t, 0.5, out.MeasuredData.Humidity.Min)
+	assert.InDelta(t, 0.68, out.MeasuredData.Humidity.Max, 1e-9)
+
+	assert.Len(t, out.Weather.Slots.Slots, 3)
+	assert.Equal(t, "SUN", out.Weather.Slots.Slots["2024-03-31 08:00"].State)
+
+	_, err = client.GetReportRange(&GetReportRangeInput{HomeID: 1, ZoneID: 2, Location: loc,
+		From: time.Date(2024, 3, 30, 0, 0, 0, 0, loc), To: time.Date(2024, 3, 29, 0, 0, 0, 0, loc)})
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid report range: 2024-03-30 is after 2024-03-29", err.Error())
+	}
+
+	_, err = client.GetReportRange(&GetReportRangeInput{HomeID: 1, ZoneID: 2, Location: loc,
+		From: time.Date(2204, 1, 1, 0, 0, 0, 0, loc), To: time.Date(2024, 1, 1, 0, 0, 0, 0, loc)})
+	assert.Error(t, err)
+	_, err = client.GetReportRange(&GetReportRangeInput{HomeID: 1, ZoneID: 2, Location: loc,
+		From: time.Date(2022, 12, 31, 0, 0, 0, 0, loc), To: time.Date(2024, 1, 1, 0, 0, 0, 0, loc)})
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid report range: 367 days from 2022-12-31, at most 366 days are allowed", err.Error())
+	}
+}
+
+func TestClient_GetReportRange_StopsOnError(t *testing.T) {
+	var requests int32
+	f := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	_, err := client.GetReportRange(&GetReportRangeInput{HomeID: 1, ZoneID: 2, Location: time.UTC, Concurrency: 1,
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)})
+	if assert.Error(t, err) {
+		assert.Equal(t, "day 2024-01-01: error: HTTP status 500: ", err.Error())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,455 @@
+package tado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot format written by TakeSnapshot
+const SnapshotVersion = 1
+
+// Snapshot contains everything that can be read about the configuration of a home.
+// It can be written as JSON and restored later with RestoreSnapshot.
+type Snapshot struct {
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Home      Home             `json:"home"`
+	Zones     []ZoneSnapshot   `json:"zones"`
+	Devices   []DeviceSnapshot `json:"devices"`
+}
+
+// ZoneSnapshot contains the configuration of a single zone
+type ZoneSnapshot struct {
+	Zone              Zone               `json:"zone"`
+	ActiveTimetable   Timetable          `json:"activeTimetable"`
+	Blocks            []ScheduleBlock    `json:"blocks"`
+	AwayConfiguration *AwayConfiguration `json:"awayConfiguration,omitempty"`
+	EarlyStart        *EarlyStart        `json:"earlyStart,omitempty"`
+	Overlay           *Overlay           `json:"overlay,omitempty"`
+}
+
+// DeviceSnapshot contains the configuration of a single device
+type DeviceSnapshot struct {
+	Device            Device       `json:"device"`
+	TemperatureOffset *Temperature `json:"temperatureOffset,omitempty"`
+}
+
+// ReadSnapshot reads a snapshot written by Snapshot.Write.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	s := new(Snapshot)
+	err := json.NewDecoder(r).Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %s", err)
+	}
+	if s.Version < 1 || s.Version > SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	return s, nil
+}
+
+// Write writes the snapshot as indented JSON.
+func (s *Snapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// TakeSnapshot reads the configuration of a home, its zones and its devices.
+// This uses several requests per zone.
+func (c *Client) TakeSnapshot(homeID int) (*Snapshot, error) {
+	home, err := c.GetHome(&GetHomeInput{HomeID: homeID})
+	if err != nil {
+		return nil, err
+	}
+	zones, err := c.GetZones(&GetZonesInput{HomeID: homeID})
+	if err != nil {
+		return nil, err
+	}
+	states, err := c.GetZoneStates(&GetZoneStatesInput{HomeID: homeID})
+	if err != nil {
+		return nil, err
+	}
+	devices, err := c.GetDevices(&GetDevicesInput{HomeID: homeID})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now(),
+		Home:      home.Home,
+		Zones:     make([]ZoneSnapshot, 0, len(zones)),
+		Devices:   make([]DeviceSnapshot, 0, len(devices)),
+	}
+
+	for _, z := range zones {
+		zs, err := c.snapshotZone(homeID, z)
+		if err != nil {
+			return nil, fmt.Errorf("zone %d: %s", z.ID, err)
+		}
+		if st, ok := states[z.ID]; ok && st.OverlayType != "" {
+			o := st.Overlay
+			zs.Overlay = &o
+		}
+		s.Zones = append(s.Zones, *zs)
+	}
+
+	for _, d := range devices {
+		ds := DeviceSnapshot{Device: d}
+		if d.HasCapability(CapabilityInsideTemperatureMeasurement) {
+			offset, err := c.GetTemperatureOffset(&GetTemperatureOffsetInput{SerialNo: d.SerialNo})
+			if err != nil {
+				return nil, fmt.Errorf("device %s: %s", d.SerialNo, err)
+			}
+			ds.TemperatureOffset = &offset.Temperature
+		}
+		s.Devices = append(s.Devices, ds)
+	}
+
+	return s, nil
+}
+
+func (c *Client) snapshotZone(homeID int, z Zone) (*ZoneSnapshot, error) {
+	zs := &ZoneSnapshot{Zone: z}
+
+	tt, err := c.GetActiveTimetable(&GetActiveTimetableInput{HomeID: homeID, ZoneID: z.ID})
+	if err != nil {
+		return nil, err
+	}
+	zs.ActiveTimetable = tt.Timetable
+
+	zs.Blocks, err = c.GetScheduleBlocks(&GetScheduleBlocksInput{HomeID: homeID, ZoneID: z.ID, TimetableID: tt.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if z.Type == ZoneTypeHeating || z.Type == ZoneTypeHotWater {
+		ac, err := c.GetAwayConfiguration(&GetAwayConfigurationInput{HomeID: homeID, ZoneID: z.ID})
+		if err != nil {
+			return nil, err
+		}
+		zs.AwayConfiguration = &ac.AwayConfiguration
+	}
+
+	if z.Type == ZoneTypeHeating {
+		es, err := c.GetEarlyStart(&GetEarlyStartInput{HomeID: homeID, ZoneID: z.ID})
+		if err != nil {
+			return nil, err
+		}
+		zs.EarlyStart = &es.EarlyStart
+	}
+
+	return zs, nil
+}
+
+// PlanRestore returns the changes needed to restore a snapshot, without applying them.
+// It takes a new snapshot of the home to compare against.
+func (c *Client) PlanRestore(s *Snapshot) (*Plan, error) {
+	current, err := c.TakeSnapshot(s.Home.ID)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(current, s), nil
+}
+
+// RestoreSnapshot reapplies the writable parts of a snapshot to the home it was taken from.
+// Zones and devices that no longer exist are skipped, use PlanRestore to review the changes first.
+func (c *Client) RestoreSnapshot(ctx context.Context, s *Snapshot) (*Plan, error) {
+	p, err := c.PlanRestore(s)
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.ApplyPlan(ctx, p)
+	return p, err
+}
+
+// diffSnapshots returns the plan to change the configuration in current into the configuration in wanted
+func diffSnapshots(current, wanted *Snapshot) *Plan {
+	homeID := wanted.Home.ID
+	unit := current.Home.TemperatureUnit
+	p := &Plan{HomeID: homeID}
+	target := fmt.Sprintf("home %d", homeID)
+
+	if cd, wd := current.Home.Details(), wanted.Home.Details(); cd != wd {
+		p.add(target, "details", fmt.Sprintf("%+v", cd), fmt.Sprintf("%+v", wd), func(c *Client) error {
+			_, err := c.PutHomeDetails(&PutHomeDetailsInput{HomeID: homeID, HomeDetails: wd})
+			return err
+		})
+	}
+	if cr, wr := current.Home.AwayRadiusInMeters, wanted.Home.AwayRadiusInMeters; cr != wr {
+		p.add(target, "away radius", formatMeters(cr), formatMeters(wr), func(c *Client) error {
+			_, err := c.PutAwayRadius(&PutAwayRadiusInput{HomeID: homeID, AwayRadius: AwayRadius{AwayRadiusInMeters: wr}})
+			return err
+		})
+	}
+
+	currentZones := make(map[int]ZoneSnapshot, len(current.Zones))
+	for _, zs := range current.Zones {
+		currentZones[zs.Zone.ID] = zs
+	}
+	for _, wz := range wanted.Zones {
+		cz, ok := currentZones[wz.Zone.ID]
+		if !ok {
+			p.Skipped = append(p.Skipped, fmt.Sprintf("zone %d %q, it does not exist", wz.Zone.ID, wz.Zone.Name))
+			continue
+		}
+		diffZoneSnapshots(p, homeID, unit, cz, wz, wanted.CreatedAt, current.CreatedAt)
+	}
+
+	currentDevices := make(map[string]DeviceSnapshot, len(current.Devices))
+	for _, ds := range current.Devices {
+		currentDevices[ds.Device.SerialNo] = ds
+	}
+	for _, wd := range wanted.Devices {
+		if wd.TemperatureOffset == nil {
+			continue
+		}
+		cd, ok := currentDevices[wd.Device.SerialNo]
+		if !ok {
+			p.Skipped = append(p.Skipped, fmt.Sprintf("device %s, it does not exist", wd.Device.SerialNo))
+			continue
+		}
+		p.diffTemperatureOffset(wd.Device.SerialNo, unit, cd.TemperatureOffset, *wd.TemperatureOffset)
+	}
+
+	return p
+}
+
+// diffZoneSnapshots adds the changes for a single zone to p, wz was taken at wantedAt and cz at now
+func diffZoneSnapshots(p *Plan, homeID int, unit TemperatureUnit, cz, wz ZoneSnapshot, wantedAt, now time.Time) {
+	zoneID := wz.Zone.ID
+	target := fmt.Sprintf("zone %d %q", zoneID, cz.Zone.Name)
+
+	p.diffZoneName(homeID, zoneID, target, cz.Zone.Name, wz.Zone.Name)
+	if cz.Zone.SupportsDazzle {
+		p.diffDazzleMode(homeID, zoneID, target, cz.Zone.DazzleMode.Enabled, wz.Zone.DazzleMode.Enabled)
+	}
+	if cz.Zone.OpenWindowDetection.Supported {
+		p.diffOpenWindowDetection(homeID, zoneID, target, cz.Zone.OpenWindowDetection, wz.Zone.OpenWindowDetection)
+	}
+
+	ct, wt := cz.ActiveTimetable.ID, wz.ActiveTimetable.ID
+	if ct != wt {
+		p.add(target, "active timetable", strconv.Itoa(ct), strconv.Itoa(wt), func(c *Client) error {
+			_, err := c.PutActiveTimetable(&PutActiveTimetableInput{HomeID: homeID, ZoneID: zoneID, Timetable: Timetable{ID: wt}})
+			return err
+		})
+	}
+	currentBlocks := cz.Blocks
+	if ct != wt {
+		// the blocks of the wanted timetable are unknown, replace all of them
+		currentBlocks = nil
+	}
+	p.diffScheduleBlocks(homeID, zoneID, target, unit, wt, currentBlocks, wz.Blocks)
+
+	if wz.AwayConfiguration != nil {
+		if cz.AwayConfiguration != nil {
+			p.diffAwayConfiguration(homeID, zoneID, target, unit, *cz.AwayConfiguration, *wz.AwayConfiguration)
+		} else {
+			p.Skipped = append(p.Skipped, fmt.Sprintf("%s: away configuration, the zone has none", target))
+		}
+	}
+	if wz.EarlyStart != nil && cz.EarlyStart != nil {
+		p.diffEarlyStart(homeID, zoneID, target, cz.EarlyStart.Enabled, wz.EarlyStart.Enabled)
+	}
+	p.diffOverlay(homeID, zoneID, target, unit, cz.Overlay, wz.Overlay, wantedAt, now)
+}
+
+func (p *Plan) diffZoneName(homeID, zoneID int, target, current, wanted string) {
+	if current == wanted {
+		return
+	}
+	p.add(target, "name", strconv.Quote(current), strconv.Quote(wanted), func(c *Client) error {
+		_, err := c.PutZoneDetails(&PutZoneDetailsInput{HomeID: homeID, ZoneID: zoneID, Name: wanted})
+		return err
+	})
+}
+
+func (p *Plan) diffDazzleMode(homeID, zoneID int, target string, current, wanted bool) {
+	if current == wanted {
+		return
+	}
+	p.add(target, "dazzle mode", formatEnabled(current), formatEnabled(wanted), func(c *Client) error {
+		_, err := c.PutDazzleMode(&PutDazzleModeInput{HomeID: homeID, ZoneID: zoneID, Enabled: wanted})
+		return err
+	})
+}
+
+func (p *Plan) diffOpenWindowDetection(homeID, zoneID int, target string, current, wanted OpenWindowDetection) {
+	if current.Enabled == wanted.Enabled && (!wanted.Enabled || current.TimeoutInSeconds == wanted.TimeoutInSeconds) {
+		return
+	}
+	p.add(target, "open window detection", formatOpenWindowDetection(current), formatOpenWindowDetection(wanted), func(c *Client) error {
+		_, err := c.PutOpenWindowDetection(&PutOpenWindowDetectionInput{
+			HomeID:           homeID,
+			ZoneID:           zoneID,
+			Enabled:          wanted.Enabled,
+			TimeoutInSeconds: wanted.TimeoutInSeconds,
+		})
+		return err
+	})
+}
+
+func (p *Plan) diffScheduleBlocks(homeID, zoneID int, target string, unit TemperatureUnit, timetableID int, current, wanted []ScheduleBlock) {
+	currentByDay := blocksByDayType(current)
+	wantedByDay := blocksByDayType(wanted)
+	dayTypes := make([]string, 0, len(wantedByDay))
+	for dt := range wantedByDay {
+		dayTypes = append(dayTypes, dt)
+	}
+	sort.Strings(dayTypes)
+
+	for _, dt := range dayTypes {
+		cb, wb := currentByDay[dt], wantedByDay[dt]
+		if reflect.DeepEqual(cb, wb) {
+			continue
+		}
+		dayType := dt
+		p.add(target, "schedule "+dt, formatBlocks(cb, unit), formatBlocks(wb, unit), func(c *Client) error {
+			_, err := c.PutScheduleBlocks(&PutScheduleBlocksInput{
+				HomeID:      homeID,
+				ZoneID:      zoneID,
+				TimetableID: timetableID,
+				DayType:     dayType,
+				Blocks:      wb,
+			})
+			return err
+		})
+	}
+}
+
+func (p *Plan) diffAwayConfiguration(homeID, zoneID int, target string, unit TemperatureUnit, current, wanted AwayConfiguration) {
+	if reflect.DeepEqual(current, wanted) {
+		return
+	}
+	p.add(target, "away configuration", formatAwayConfiguration(current, unit), formatAwayConfiguration(wanted, unit), func(c *Client) error {
+		_, err := c.PutAwayConfiguration(&PutAwayConfigurationInput{HomeID: homeID, ZoneID: zoneID, AwayConfiguration: wanted})
+		return err
+	})
+}
+
+func (p *Plan) diffEarlyStart(homeID, zoneID int, target string, current, wanted bool) {
+	if current == wanted {
+		return
+	}
+	p.add(target, "early start", formatEnabled(current), formatEnabled(wanted), func(c *Client) error {
+		_, err := c.PutEarlyStart(&PutEarlyStartInput{HomeID: homeID, ZoneID: zoneID, EarlyStart: EarlyStart{Enabled: wanted}})
+		return err
+	})
+}
+
+// diffOverlay adds the change from the current to the wanted overlay, wanted was read at wantedAt.
+// A wanted timer overlay is only set for the time it had left at now, it is skipped when it has expired.
+func (p *Plan) diffOverlay(homeID, zoneID int, target string, unit TemperatureUnit, current, wanted *Overlay, wantedAt, now time.Time) {
+	switch {
+	case current == nil && wanted == nil:
+		return
+	case wanted == nil:
+		p.add(target, "overlay", formatOverlay(current, unit), formatOverlay(nil, unit), func(c *Client) error {
+			_, err := c.DeleteOverlay(&DeleteOverlayInput{HomeID: homeID, ZoneID: zoneID})
+			return err
+		})
+		return
+	case current != nil && current.Setting == wanted.Setting && current.Termination.Type == wanted.Termination.Type:
+		return
+	}
+	in := wanted.Input()
+	if wanted.Termination.Type == TerminationTypeTimer {
+		expiry := wanted.Termination.Expiry
+		if expiry.IsZero() {
+			expiry = wantedAt.Add(time.Duration(wanted.Termination.RemainingTimeInSeconds) * time.Second)
+		}
+		remaining := expiry.Sub(now)
+		if remaining < time.Second {
+			p.Skipped = append(p.Skipped, fmt.Sprintf("%s: overlay %s, its timer has expired", target, formatOverlay(wanted, unit)))
+			return
+		}
+		in.Termination.DurationInSeconds = int(remaining / time.Second)
+	}
+	p.add(target, "overlay", formatOverlay(current, unit), formatOverlay(wanted, unit), func(c *Client) error {
+		_, err := c.PutOverlay(&PutOverlayInput{HomeID: homeID, ZoneID: zoneID, OverlayInput: in})
+		return err
+	})
+}
+
+func (p *Plan) diffTemperatureOffset(serialNo string, unit TemperatureUnit, current *Temperature, wanted Temperature) {
+	if current != nil && current.OffsetEqual(wanted) {
+		return
+	}
+	from := "unknown"
+	if current != nil {
+		from = current.FormatOffset(unit)
+	}
+	p.add("device "+serialNo, "temperature offset", from, wanted.FormatOffset(unit), func(c *Client) error {
+		_, err := c.PutTemperatureOffset(&PutTemperatureOffsetInput{SerialNo: serialNo, Offset: wanted})
+		return err
+	})
+}
+
+func blocksByDayType(blocks []ScheduleBlock) map[string][]ScheduleBlock {
+	m := make(map[string][]ScheduleBlock)
+	for _, b := range blocks {
+		m[b.DayType] = append(m[b.DayType], b)
+	}
+	return m
+}
+
+func formatEnabled(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func formatMeters(m float64) string {
+	return strconv.FormatFloat(m, 'f', -1, 64) + "m"
+}
+
+func formatOpenWindowDetection(owd OpenWindowDetection) string {
+	if !owd.Enabled {
+		return formatEnabled(false)
+	}
+	return fmt.Sprintf("enabled for %s", time.Duration(owd.TimeoutInSeconds)*time.Second)
+}
+
+func formatSetting(s Setting, unit TemperatureUnit) string {
+	if s.Power == PowerOn && (s.Temperature != Temperature{}) {
+		return fmt.Sprintf("%s %s %s", s.Type, s.Power, s.Temperature.Format(unit))
+	}
+	return fmt.Sprintf("%s %s", s.Type, s.Power)
+}
+
+func formatBlocks(blocks []ScheduleBlock, unit TemperatureUnit) string {
+	if len(blocks) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		parts = append(parts, fmt.Sprintf("%s-%s %s", b.Start, b.End, formatSetting(b.Setting, unit)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatAwayConfiguration(ac AwayConfiguration, unit TemperatureUnit) string {
+	if ac.AutoAdjust {
+		return fmt.Sprintf("auto adjust with comfort level %d", ac.ComfortLevel)
+	}
+	if ac.Setting == nil {
+		return "no setting"
+	}
+	return formatSetting(*ac.Setting, unit)
+}
+
+func formatOverlay(o *Overlay, unit TemperatureUnit) string {
+	if o == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s until %s", formatSetting(o.Setting, unit), o.Termination.Type)
+}
@@ -0,0 +1,231 @@
+package tado
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// snapshotTestHandler serves a home with one heating zone and one device, and records all changes
+func snapshotTestHandler(t *testing.T, changes *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			b, _ := ioutil.ReadAll(r.Body)
+			*changes = append(*changes, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(b)))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/homes/12345":
+			_, _ = fmt.Fprint(w, `{"id": 12345, "name": "Home", "temperatureUnit": "CELSIUS", "awayRadiusInMeters": 400}`)
+		case "/v2/homes/12345/zones":
+			_, _ = fmt.Fprint(w, `[{"id": 1, "name": "Living Room", "type": "HEATING", "devices": [{"serialNo": "VA01"}], "openWindowDetection": {"supported": true, "enabled": true, "timeoutInSeconds": 900}}]`)
+		case "/v2/homes/12345/zoneStates":
+			_, _ = fmt.Fprint(w, `{"zoneStates": {"1": {"overlayType": "MANUAL", "overlay": {"type": "MANUAL", "setting": {"type": "HEATING", "power": "OFF"}, "termination": {"type": "MANUAL"}}}}}`)
+		case "/v2/homes/12345/devices":
+			_, _ = fmt.Fprint(w, `[{"serialNo": "VA01", "characteristics": {"capabilities": ["INSIDE_TEMPERATURE_MEASUREMENT"]}}, {"serialNo": "IB01"}]`)
+		case "/v2/homes/12345/zones/1/schedule/activeTimetable":
+			_, _ = fmt.Fprint(w, `{"id": 0, "type": "ONE_DAY"}`)
+		case "/v2/homes/12345/zones/1/schedule/timetables/0/blocks":
+			_, _ = fmt.Fprint(w, `[{"dayType": "MONDAY_TO_SUNDAY", "start": "00:00", "end": "00:00", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 18}}}]`)
+		case "/v2/homes/12345/zones/1/schedule/awayConfiguration":
+			_, _ = fmt.Fprint(w, `{"type": "HEATING", "autoAdjust": false, "comfortLevel": 50, "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 15}}}`)
+		case "/v2/homes/12345/zones/1/earlyStart":
+			_, _ = fmt.Fprint(w, `{"enabled": true}`)
+		case "/v2/devices/VA01/temperatureOffset":
+			_, _ = fmt.Fprint(w, `{"celsius": 0, "fahrenheit": 0}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestClient_TakeSnapshot(t *testing.T) {
+	var changes []string
+	client, server := setupTestClientAndServer(snapshotTestHandler(t, &changes))
+	defer server.Close()
+
+	s, err := client.TakeSnapshot(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, SnapshotVersion, s.Version)
+	assert.Equal(t, "Home", s.Home.Name)
+	if assert.Len(t, s.Zones, 1) {
+		z := s.Zones[0]
+		assert.Equal(t, "Living Room", z.Zone.Name)
+		assert.Len(t, z.Blocks, 1)
+		if assert.NotNil(t, z.EarlyStart) {
+			assert.True(t, z.EarlyStart.Enabled)
+		}
+		if assert.NotNil(t, z.AwayConfiguration) {
+			assert.Equal(t, 50, z.AwayConfiguration.ComfortLevel)
+		}
+		if assert.NotNil(t, z.Overlay) {
+			assert.Equal(t, PowerOff, z.Overlay.Setting.Power)
+		}
+	}
+	if assert.Len(t, s.Devices, 2) {
+		assert.NotNil(t, s.Devices[0].TemperatureOffset)
+		assert.Nil(t, s.Devices[1].TemperatureOffset)
+	}
+	assert.Empty(t, changes)
+
+	// write and read the snapshot
+	buf := new(bytes.Buffer)
+	assert.NoError(t, s.Write(buf))
+	r, err := ReadSnapshot(buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, s.Zones, r.Zones)
+		assert.Equal(t, s.Devices, r.Devices)
+	}
+
+	_, err = ReadSnapshot(strings.NewReader(`{"version": 99}`))
+	if assert.Error(t, err) {
+		assert.Equal(t, "unsupported snapshot version 99", err.Error())
+	}
+}
+
+func TestClient_RestoreSnapshot(t *testing.T) {
+	var changes []string
+	client, server := setupTestClientAndServer(snapshotTestHandler(t, &changes))
+	defer server.Close()
+
+	s, err := client.TakeSnapshot(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing changed
+	p, err := client.PlanRestore(s)
+	if assert.NoError(t, err) {
+		assert.True(t, p.Empty())
+	}
+
+	// change the snapshot so it differs from the current configuration
+	s.Home.AwayRadiusInMeters = 1000
+	s.Zones[0].Zone.Name = "Lounge"
+	s.Zones[0].Zone.OpenWindowDetection.TimeoutInSeconds = 600
+	s.Zones[0].Blocks[0].Setting.Temperature = Temperature{Celsius: 19.5}
+	s.Zones[0].EarlyStart.Enabled = false
+	s.Zones[0].Overlay = nil
+	s.Devices[0].TemperatureOffset = &Temperature{Celsius: -1}
+	s.Zones = append(s.Zones, ZoneSnapshot{Zone: Zone{ID: 9, Name: "Attic"}})
+
+	p, err = client.PlanRestore(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, changes, "PlanRestore must not change anything")
+	assert.Equal(t, `home 12345: 7 change(s)
+~ home 12345: away radius: 400m -> 1000m
+~ zone 1 "Living Room": name: "Living Room" -> "Lounge"
+~ zone 1 "Living Room": open window detection: enabled for 15m0s -> enabled for 10m0s
+~ zone 1 "Living Room": schedule MONDAY_TO_SUNDAY: 00:00-00:00 HEATING ON 18°C -> 00:00-00:00 HEATING ON 19.5°C
+~ zone 1 "Living Room": early start: enabled -> disabled
+~ zone 1 "Living Room": overlay: HEATING OFF until MANUAL -> none
+~ device VA01: temperature offset: 0°C -> -1°C
+! skipped zone 9 "Attic", it does not exist
+`, p.String())
+
+	p, err = client.RestoreSnapshot(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, p.Changes, 7)
+	assert.Equal(t, []string{
+		`PUT /v2/homes/12345/awayRadiusInMeters {"awayRadiusInMeters":1000}`,
+		`PUT /v2/homes/12345/zones/1/details {"name":"Lounge"}`,
+		`PUT /v2/homes/12345/zones/1/openWindowDetection {"enabled":true,"timeoutInSeconds":600}`,
+		`PUT /v2/homes/12345/zones/1/schedule/timetables/0/blocks/MONDAY_TO_SUNDAY [{"dayType":"MONDAY_TO_SUNDAY","start":"00:00","end":"00:00","geolocationOverride":false,"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":19.5}}}]`,
+		`PUT /v2/homes/12345/zones/1/earlyStart {"enabled":false}`,
+		`DELETE /v2/homes/12345/zones/1/overlay`,
+		`PUT /v2/devices/VA01/temperatureOffset {"celsius":-1}`,
+	}, changes)
+}
+
+func TestClient_ApplyPlan_Canceled(t *testing.T) {
+	client := NewClient("", "")
+	applied := 0
+	p := new(Plan)
+	p.add("home 1", "test", "a", "b", func(c *Client) error {
+		applied++
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	n, err := client.ApplyPlan(ctx, p)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 0, applied)
+}
+
+func TestDiffSnapshots_TimerOverlay(t *testing.T) {
+	var bodies []string
+	client, server := setupTestClientAndServer(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(b)))
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	defer server.Close()
+
+	takenAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	timer := func(remaining int, expiry time.Time) *Overlay {
+		return &Overlay{
+			Type:    OverlayTypeManual,
+			Setting: Setting{Type: ZoneTypeHeating, Power: PowerOn, Temperature: Temperature{Celsius: 21}},
+			Termination: Termination{
+				Type:                   TerminationTypeTimer,
+				DurationInSeconds:      3600,
+				RemainingTimeInSeconds: remaining,
+				Expiry:                 expiry,
+			},
+		}
+	}
+	wanted := &Snapshot{
+		CreatedAt: takenAt,
+		Home:      Home{ID: 12345},
+		Zones: []ZoneSnapshot{
+			{Zone: Zone{ID: 1, Name: "Living Room"}, Overlay: timer(1800, time.Time{})},
+			{Zone: Zone{ID: 2, Name: "Bedroom"}, Overlay: timer(1800, takenAt.Add(30*time.Minute))},
+			{Zone: Zone{ID: 3, Name: "Kitchen"}, Overlay: timer(600, time.Time{})},
+			{Zone: Zone{ID: 4, Name: "Hall"}, Overlay: timer(0, time.Time{})},
+		},
+	}
+	current := &Snapshot{
+		CreatedAt: takenAt.Add(20 * time.Minute),
+		Home:      Home{ID: 12345},
+		Zones: []ZoneSnapshot{
+			{Zone: Zone{ID: 1, Name: "Living Room"}},
+			{Zone: Zone{ID: 2, Name: "Bedroom"}},
+			{Zone: Zone{ID: 3, Name: "Kitchen"}},
+			{Zone: Zone{ID: 4, Name: "Hall"}},
+		},
+	}
+
+	// timers are restored for the time they have left, expired timers are skipped
+	p := diffSnapshots(current, wanted)
+	assert.Equal(t, `home 12345: 2 change(s)
+~ zone 1 "Living Room": overlay: none -> HEATING ON 21°C until TIMER
+~ zone 2 "Bedroom": overlay: none -> HEATING ON 21°C until TIMER
+! skipped zone 3 "Kitchen": overlay HEATING ON 21°C until TIMER, its timer has expired
+! skipped zone 4 "Hall": overlay HEATING ON 21°C until TIMER, its timer has expired
+`, p.String())
+
+	_, err := client.ApplyPlan(context.Background(), p)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`PUT /v2/homes/12345/zones/1/overlay {"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":21}},"termination":{"type":"TIMER","durationInSeconds":600}}`,
+		`PUT /v2/homes/12345/zones/2/overlay {"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":21}},"termination":{"type":"TIMER","durationInSeconds":600}}`,
+	}, bodies)
+}
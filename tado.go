@@ -1,9 +1,11 @@
 package tado
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"github.com/SebastiaanKlippert/go-tado/tadoauth"
 )
@@ -17,48 +19,45 @@ type authClient interface {
 type Client struct {
 	HTTPClient *http.Client
 
-	authClient            authClient
-	baseURL               string
-	username, password    string
-	tr                    *tadoauth.TokenResponse
-	accessTokenValidUntil time.Time
-	mutex                 *sync.Mutex
+	// DryRun enables dry-run mode, mutating requests (PUT, POST, DELETE) are then not sent to Tado but are
+	// recorded and logged with the logger set by SetLogger, and a synthetic response is returned.
+	// GET requests are still sent.
+	DryRun bool
+	// AuditSink receives an entry for every mutating request when it is set.
+	AuditSink AuditSink
+	// Interceptors are wrapped around every API call, the first interceptor is the outermost.
+	Interceptors []Interceptor
+	// Cache enables caching of GET responses when it is set, see NewCache.
+	Cache *Cache
+	// CoalesceRequests shares one HTTP round trip between identical GET calls made at the same time,
+	// every caller still gets its own decoded output.
+	CoalesceRequests bool
+
+	authClient         authClient
+	baseURL            string
+	username, password string
+	token              atomic.Pointer[token]
+	refreshMutex       sync.Mutex
+	tokenStore         TokenStore
+	tokenAccount       string
+	budget             *rateBudget
+	logger             *slog.Logger
+	dryRunRequests     []DryRunRequest
+	dryRunMutex        sync.Mutex
+	coalescer          coalescer
+	backgroundMutex    sync.Mutex
+	backgroundStops    []context.CancelFunc
 }
 
 // NewClient returns a new Tado client.
 func NewClient(username, password string) *Client {
 	return &Client{
-		username:              username,
-		password:              password,
-		accessTokenValidUntil: time.Time{},
-		baseURL:               defaultBaseURL,
-		mutex:                 new(sync.Mutex),
-		authClient:            tadoauth.NewClient(),
-		HTTPClient:            http.DefaultClient,
-	}
-}
-
-func (c *Client) validateAccessToken() error {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	// check if access token is valid for at least 5 more seconds
-	if c.accessTokenValidUntil.After(time.Now().Add(5 * time.Second)) {
-		return nil
-	}
-	// access token is expired, or will expire soon, get a new one
-	var err error
-	if c.tr != nil && c.tr.RefreshToken != "" {
-		// exchange refresh token for new access token
-		c.tr, err = c.authClient.RefreshToken(c.tr.RefreshToken)
-	} else {
-		// get new token based on username and password
-		c.tr, err = c.authClient.GetToken(c.username, c.password)
-	}
-	if err != nil {
-		return err
+		username:   username,
+		password:   password,
+		baseURL:    defaultBaseURL,
+		authClient: tadoauth.NewClient(),
+		HTTPClient: http.DefaultClient,
 	}
-	c.accessTokenValidUntil = time.Now().Add(time.Duration(c.tr.ExpiresIn) * time.Second)
-	return nil
 }
 
 // GetMe returns the users data from the API.
@@ -82,6 +81,27 @@ func (c *Client) GetHome(in *GetHomeInput) (*GetHomeOutput, error) {
 	return out, nil
 }
 
+// PutHomeDetails updates the name, contact details, address and geolocation of a home.
+// All details are replaced, use Home.Details to start from the current values.
+func (c *Client) PutHomeDetails(in *PutHomeDetailsInput) (*PutHomeDetailsOutput, error) {
+	out := new(PutHomeDetailsOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutAwayRadius updates the distance from home at which a home is considered away.
+func (c *Client) PutAwayRadius(in *PutAwayRadiusInput) (*PutAwayRadiusOutput, error) {
+	out := new(PutAwayRadiusOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GetDevices returns the devices in a home.
 func (c *Client) GetDevices(in *GetDevicesInput) (GetDevicesOutput, error) {
 	out := make(GetDevicesOutput, 0)
@@ -92,6 +112,26 @@ func (c *Client) GetDevices(in *GetDevicesInput) (GetDevicesOutput, error) {
 	return out, nil
 }
 
+// GetTemperatureOffset returns the temperature offset of a device.
+func (c *Client) GetTemperatureOffset(in *GetTemperatureOffsetInput) (*GetTemperatureOffsetOutput, error) {
+	out := new(GetTemperatureOffsetOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutTemperatureOffset changes the temperature offset of a device, used to correct its measured temperature.
+func (c *Client) PutTemperatureOffset(in *PutTemperatureOffsetInput) (*PutTemperatureOffsetOutput, error) {
+	out := new(PutTemperatureOffsetOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GetUsers returns the users in a home.
 func (c *Client) GetUsers(in *GetUsersInput) (GetUsersOutput, error) {
 	out := make(GetUsersOutput, 0)
@@ -102,6 +142,56 @@ func (c *Client) GetUsers(in *GetUsersInput) (GetUsersOutput, error) {
 	return out, nil
 }
 
+// DeleteUser removes a user from a home.
+func (c *Client) DeleteUser(in *DeleteUserInput) (*DeleteUserOutput, error) {
+	out := new(DeleteUserOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetInvitations returns the pending invitations for a home.
+func (c *Client) GetInvitations(in *GetInvitationsInput) (GetInvitationsOutput, error) {
+	out := make(GetInvitationsOutput, 0)
+	err := c.do(in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PostInvitation invites a user by email to join a home.
+func (c *Client) PostInvitation(in *PostInvitationInput) (*PostInvitationOutput, error) {
+	out := new(PostInvitationOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResendInvitation sends the invitation email for a pending invitation again.
+func (c *Client) ResendInvitation(in *ResendInvitationInput) (*ResendInvitationOutput, error) {
+	out := new(ResendInvitationOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteInvitation revokes a pending invitation.
+func (c *Client) DeleteInvitation(in *DeleteInvitationInput) (*DeleteInvitationOutput, error) {
+	out := new(DeleteInvitationOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GetZones returns the zones for a single home.
 func (c *Client) GetZones(in *GetZonesInput) (GetZonesOutput, error) {
 	out := make(GetZonesOutput, 0)
@@ -112,6 +202,76 @@ func (c *Client) GetZones(in *GetZonesInput) (GetZonesOutput, error) {
 	return out, nil
 }
 
+// PutZoneDetails renames a zone.
+func (c *Client) PutZoneDetails(in *PutZoneDetailsInput) (*PutZoneDetailsOutput, error) {
+	out := new(PutZoneDetailsOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutDazzleMode enables or disables dazzle mode for a zone.
+func (c *Client) PutDazzleMode(in *PutDazzleModeInput) (*PutDazzleModeOutput, error) {
+	out := new(PutDazzleModeOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutZoneOrder changes the order in which the zones of a home are listed.
+func (c *Client) PutZoneOrder(in *PutZoneOrderInput) (*PutZoneOrderOutput, error) {
+	out := new(PutZoneOrderOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PostZoneDevice assigns a device to a zone, moving it from the zone it is currently in.
+func (c *Client) PostZoneDevice(in *PostZoneDeviceInput) (*PostZoneDeviceOutput, error) {
+	out := new(PostZoneDeviceOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetEarlyStart returns the early start setting of a zone.
+func (c *Client) GetEarlyStart(in *GetEarlyStartInput) (*GetEarlyStartOutput, error) {
+	out := new(GetEarlyStartOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutEarlyStart enables or disables early start for a zone.
+func (c *Client) PutEarlyStart(in *PutEarlyStartInput) (*PutEarlyStartOutput, error) {
+	out := new(PutEarlyStartOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutOpenWindowDetection changes the open window detection settings of a zone.
+func (c *Client) PutOpenWindowDetection(in *PutOpenWindowDetectionInput) (*PutOpenWindowDetectionOutput, error) {
+	out := new(PutOpenWindowDetectionOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GetHomeState returns the presence state for a single home.
 func (c *Client) GetHomeState(in *GetHomeStateInput) (*GetHomeStateOutput, error) {
 	out := new(GetHomeStateOutput)
@@ -132,6 +292,103 @@ func (c *Client) GetZoneState(in *GetZoneStateInput) (*GetZoneStateOutput, error
 	return out, nil
 }
 
+// GetZoneStates returns the state of all zones within a home in a single request.
+func (c *Client) GetZoneStates(in *GetZoneStatesInput) (GetZoneStatesOutput, error) {
+	out := new(zoneStatesResponse)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	if out.ZoneStates == nil {
+		return make(GetZoneStatesOutput), nil
+	}
+	return out.ZoneStates, nil
+}
+
+// GetZoneTemperatures returns the measured inside temperature of all zones within a home, keyed by zone ID.
+// It uses a single request for all zones.
+func (c *Client) GetZoneTemperatures(in *GetZoneStatesInput) (map[int]Temperature, error) {
+	states, err := c.GetZoneStates(in)
+	if err != nil {
+		return nil, err
+	}
+	temperatures := make(map[int]Temperature, len(states))
+	for id, zs := range states {
+		temperatures[id] = zs.SensorDataPoints.InsideTemperature.Temperature
+	}
+	return temperatures, nil
+}
+
+// GetZoneCapabilities returns the supported settings and temperature range of a zone.
+func (c *Client) GetZoneCapabilities(in *GetZoneCapabilitiesInput) (*GetZoneCapabilitiesOutput, error) {
+	out := new(GetZoneCapabilitiesOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetActiveTimetable returns the active timetable of the schedule of a zone.
+func (c *Client) GetActiveTimetable(in *GetActiveTimetableInput) (*GetActiveTimetableOutput, error) {
+	out := new(GetActiveTimetableOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutActiveTimetable changes the active timetable of the schedule of a zone.
+func (c *Client) PutActiveTimetable(in *PutActiveTimetableInput) (*PutActiveTimetableOutput, error) {
+	out := new(PutActiveTimetableOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetScheduleBlocks returns the blocks of a timetable of a zone schedule.
+func (c *Client) GetScheduleBlocks(in *GetScheduleBlocksInput) (GetScheduleBlocksOutput, error) {
+	out := make(GetScheduleBlocksOutput, 0)
+	err := c.do(in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutScheduleBlocks replaces the blocks of a single day type in a timetable of a zone schedule.
+func (c *Client) PutScheduleBlocks(in *PutScheduleBlocksInput) (PutScheduleBlocksOutput, error) {
+	out := make(PutScheduleBlocksOutput, 0)
+	err := c.do(in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAwayConfiguration returns the setting a zone uses when everyone is away.
+func (c *Client) GetAwayConfiguration(in *GetAwayConfigurationInput) (*GetAwayConfigurationOutput, error) {
+	out := new(GetAwayConfigurationOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PutAwayConfiguration changes the setting a zone uses when everyone is away.
+func (c *Client) PutAwayConfiguration(in *PutAwayConfigurationInput) (*PutAwayConfigurationOutput, error) {
+	out := new(PutAwayConfigurationOutput)
+	err := c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GetWeather returns the weather info for a home.
 func (c *Client) GetWeather(in *GetWeatherInput) (*GetWeatherOutput, error) {
 	out := new(GetWeatherOutput)
@@ -154,8 +411,40 @@ func (c *Client) GetDayReport(in *GetDayReportInput) (*GetDayReportOutput, error
 
 // PutOverlay sets an overlay in a zone, it can be used to contol settings overruling a schema.
 // For example to set the heating or hot water.
+// Overlays that can never be valid, such as a timer without a duration, are not sent.
+// Use NewOverlay to build an overlay that is fully validated.
 func (c *Client) PutOverlay(in *PutOverlayInput) (*PutOverlayOutput, error) {
+	err := in.validateStructure()
+	if err != nil {
+		return nil, err
+	}
 	out := new(PutOverlayOutput)
+	err = c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PostOverlays sets the overlays of multiple zones in a home in a single request.
+// Use SetOverlays to fall back to one request per zone when this is not supported.
+// Overlays that can never be valid, such as a timer without a duration, are not sent.
+func (c *Client) PostOverlays(in *PostOverlaysInput) (*PostOverlaysOutput, error) {
+	err := validateZoneOverlays(in.Overlays, OverlayInput.validateStructure)
+	if err != nil {
+		return nil, err
+	}
+	out := new(PostOverlaysOutput)
+	err = c.do(in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOverlays deletes the overlays of multiple zones in a home in a single request.
+func (c *Client) DeleteOverlays(in *DeleteOverlaysInput) (*DeleteOverlaysOutput, error) {
+	out := new(DeleteOverlaysOutput)
 	err := c.do(in, out)
 	if err != nil {
 		return nil, err
@@ -16,9 +16,7 @@ func TestNewClient(t *testing.T) {
 	c := NewClient("", "")
 
 	assert.NotNil(t, c.HTTPClient, "HTTP client is nil")
-	assert.NotNil(t, c.mutex, "mutex is nil")
-	assert.Nil(t, c.tr, "tr is not nil")
-	assert.Equal(t, c.accessTokenValidUntil, time.Time{})
+	assert.Nil(t, c.token.Load(), "token is not nil")
 	assert.Equal(t, defaultBaseURL, c.baseURL, "baseURL is incorrect")
 }
 
@@ -40,9 +38,9 @@ func (m *mockAuthClient) RefreshToken(refreshToken string) (*tadoauth.TokenRespo
 func TestClient_validateAccessToken(t *testing.T) {
 	// create new client with expired token
 	c := NewClient("username1", "password1")
-	c.tr = &tadoauth.TokenResponse{
+	c.setToken(&tadoauth.TokenResponse{
 		RefreshToken: "",
-	}
+	}, time.Time{})
 
 	// construct mock auth client
 	mockAuth := new(mockAuthClient)
@@ -63,10 +61,9 @@ func TestClient_validateAccessToken(t *testing.T) {
 	assert.Equal(t, "password1", mockAuth.password)
 
 	// now check if refreshtoken method is being called
-	c.accessTokenValidUntil = time.Time{}
-	c.tr = &tadoauth.TokenResponse{
+	c.setToken(&tadoauth.TokenResponse{
 		RefreshToken: "fakeRefreshToken",
-	}
+	}, time.Time{})
 
 	_, err = c.GetMe()
 
@@ -77,11 +74,11 @@ func TestClient_validateAccessToken(t *testing.T) {
 func setupTestClientAndServer(hf http.HandlerFunc) (*Client, *httptest.Server) {
 	s := httptest.NewServer(hf)
 	c := NewClient("username", "password")
-	c.accessTokenValidUntil = time.Now().Add(time.Hour) // to ensure we don't go to Tado authentication
 	c.baseURL = s.URL
-	c.tr = &tadoauth.TokenResponse{
+	// ensure we don't go to Tado authentication
+	c.setToken(&tadoauth.TokenResponse{
 		AccessToken: "fakeToken",
-	}
+	}, time.Now().Add(time.Hour))
 	return c, s
 }
 
@@ -137,6 +134,89 @@ func TestClient_GetHome(t *testing.T) {
 	}
 }
 
+func TestClient_PutHomeDetails(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/details", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"Beach house","contactDetails":{"name":"SK","email":"sk@example.com","phone":""},"address":{"addressLine1":"Main street 1","addressLine2":"","zipCode":"1234AB","city":"Amsterdam","state":"","country":"NLD"},"geolocation":{"latitude":52.37,"longitude":4.89}}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	home := Home{
+		Name: "Home",
+		ContactDetails: ContactDetails{
+			Name:  "SK",
+			Email: "sk@example.com",
+		},
+		Address: Address{
+			AddressLine1: "Main street 1",
+			ZipCode:      "1234AB",
+			City:         "Amsterdam",
+			Country:      "NLD",
+		},
+		Geolocation: Geolocation{
+			Latitude:  52.37,
+			Longitude: 4.89,
+		},
+	}
+
+	in := &PutHomeDetailsInput{
+		HomeID:      12345,
+		HomeDetails: home.Details(),
+	}
+	in.Name = "Beach house"
+
+	r, err := client.PutHomeDetails(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
+	}
+}
+
+func TestClient_PutAwayRadius(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/awayRadiusInMeters", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"awayRadiusInMeters":750.5}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutAwayRadiusInput{
+		HomeID: 12345,
+		AwayRadius: AwayRadius{
+			AwayRadiusInMeters: 750.5,
+		},
+	}
+
+	r, err := client.PutAwayRadius(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
+	}
+}
+
 func TestClient_GetDevices(t *testing.T) {
 
 	called := false
@@ -166,6 +246,87 @@ func TestClient_GetDevices(t *testing.T) {
 	}
 }
 
+func TestClient_GetTemperatureOffset(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/devices/VA0123456789/temperatureOffset", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"celsius": -0.5, "fahrenheit": -0.9}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetTemperatureOffsetInput{
+		SerialNo: "VA0123456789",
+	}
+
+	out, err := client.GetTemperatureOffset(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.Equal(t, -0.5, out.Celsius)
+	}
+}
+
+func TestClient_PutTemperatureOffset(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/devices/VA0123456789/temperatureOffset", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"celsius":0}`+"\n", string(b))
+		_, _ = fmt.Fprint(w, `{"celsius": 0, "fahrenheit": 0}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutTemperatureOffsetInput{
+		SerialNo: "VA0123456789",
+	}
+
+	out, err := client.PutTemperatureOffset(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.Equal(t, 0.0, out.Celsius)
+	}
+
+	// offsets are differences, 0°F is sent as 0°C
+	in.Offset = Temperature{Fahrenheit: 0}
+	_, err = client.PutTemperatureOffset(in)
+	assert.NoError(t, err)
+}
+
+func TestClient_PutTemperatureOffset_Fahrenheit(t *testing.T) {
+
+	f := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"celsius":-1}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	_, err := client.PutTemperatureOffset(&PutTemperatureOffsetInput{
+		SerialNo: "VA0123456789",
+		Offset:   Temperature{Fahrenheit: -1.8},
+	})
+	assert.NoError(t, err)
+}
+
 func TestClient_GetUsers(t *testing.T) {
 
 	called := false
@@ -195,192 +356,883 @@ func TestClient_GetUsers(t *testing.T) {
 	}
 }
 
-func TestClient_GetZones(t *testing.T) {
+func TestClient_DeleteUser(t *testing.T) {
 
 	called := false
 	f := func(w http.ResponseWriter, r *http.Request) {
 		called = true
-		assert.Equal(t, "/v2/homes/12345/zones", r.URL.Path)
-		assert.Equal(t, http.MethodGet, r.Method)
-		_, _ = fmt.Fprint(w, `[{"id": 1}, {"id": 2}]`)
+		assert.Equal(t, "/v2/homes/12345/users/abc123", r.URL.Path)
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNoContent)
 	}
 
 	client, server := setupTestClientAndServer(f)
 	defer server.Close()
 
-	in := &GetZonesInput{
+	in := &DeleteUserInput{
 		HomeID: 12345,
+		UserID: "abc123",
 	}
 
-	z, err := client.GetZones(in)
+	r, err := client.DeleteUser(in)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.True(t, called)
-	if assert.NotEmpty(t, z) && assert.Equal(t, 2, len(z)) {
-		assert.Equal(t, 1, z[0].ID)
-		assert.Equal(t, 2, z[1].ID)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
 	}
 }
 
-func TestClient_GetHomeState(t *testing.T) {
+func TestClient_GetInvitations(t *testing.T) {
 
 	called := false
 	f := func(w http.ResponseWriter, r *http.Request) {
 		called = true
-		assert.Equal(t, "/v2/homes/12345/state", r.URL.Path)
+		assert.Equal(t, "/v2/homes/12345/invitations", r.URL.Path)
 		assert.Equal(t, http.MethodGet, r.Method)
-		_, _ = fmt.Fprint(w, `{"presence": "HOME"}`)
+		_, _ = fmt.Fprint(w, `[{"token": "t1", "email": "a@example.com"}, {"token": "t2", "email": "b@example.com"}]`)
 	}
 
 	client, server := setupTestClientAndServer(f)
 	defer server.Close()
 
-	in := &GetHomeStateInput{
+	in := &GetInvitationsInput{
 		HomeID: 12345,
 	}
 
-	s, err := client.GetHomeState(in)
+	i, err := client.GetInvitations(in)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.True(t, called)
-	if assert.NotNil(t, s) {
-		assert.Equal(t, HomeStateHome, s.Presence)
+	if assert.NotEmpty(t, i) && assert.Equal(t, 2, len(i)) {
+		assert.Equal(t, "t1", i[0].Token)
+		assert.Equal(t, "b@example.com", i[1].Email)
 	}
 }
 
-func TestClient_GetZoneState(t *testing.T) {
+func TestClient_PostInvitation(t *testing.T) {
 
 	called := false
 	f := func(w http.ResponseWriter, r *http.Request) {
 		called = true
-		assert.Equal(t, "/v2/homes/12345/zones/2/state", r.URL.Path)
-		assert.Equal(t, http.MethodGet, r.Method)
-		_, _ = fmt.Fprint(w, `{"tadoMode": "HOME"}`)
+		assert.Equal(t, "/v2/homes/12345/invitations", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"email":"tenant@example.com"}`+"\n", string(b))
+		_, _ = fmt.Fprint(w, `{"token": "t1", "email": "tenant@example.com", "home": {"id": 12345}}`)
 	}
 
 	client, server := setupTestClientAndServer(f)
 	defer server.Close()
 
-	in := &GetZoneStateInput{
+	in := &PostInvitationInput{
 		HomeID: 12345,
-		ZoneID: 2,
+		Email:  "tenant@example.com",
 	}
 
-	s, err := client.GetZoneState(in)
+	i, err := client.PostInvitation(in)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.True(t, called)
-	if assert.NotNil(t, s) {
-		assert.Equal(t, "HOME", s.TadoMode)
+	if assert.NotNil(t, i) {
+		assert.Equal(t, "t1", i.Token)
+		assert.Equal(t, 12345, i.Home.ID)
 	}
 }
 
-func TestClient_GetWeather(t *testing.T) {
+func TestClient_ResendInvitation(t *testing.T) {
 
 	called := false
 	f := func(w http.ResponseWriter, r *http.Request) {
 		called = true
-		assert.Equal(t, "/v2/homes/12345/weather", r.URL.Path)
-		assert.Equal(t, http.MethodGet, r.Method)
-		_, _ = fmt.Fprint(w, `{"outsideTemperature": {"celsius": 8.50}}`)
+		assert.Equal(t, "/v2/homes/12345/invitations/t1/resend", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusNoContent)
 	}
 
 	client, server := setupTestClientAndServer(f)
 	defer server.Close()
 
-	in := &GetWeatherInput{
+	in := &ResendInvitationInput{
 		HomeID: 12345,
+		Token:  "t1",
 	}
 
-	w, err := client.GetWeather(in)
+	r, err := client.ResendInvitation(in)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.True(t, called)
-	if assert.NotNil(t, w) {
-		assert.Equal(t, 8.5, w.OutsideTemperature.Celsius)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
 	}
 }
 
-func TestClient_GetDayReport(t *testing.T) {
+func TestClient_DeleteInvitation(t *testing.T) {
 
 	called := false
 	f := func(w http.ResponseWriter, r *http.Request) {
 		called = true
-		assert.Equal(t, "/v2/homes/12345/zones/2/dayReport", r.URL.Path)
-		assert.Equal(t, "date=2020-12-31", r.URL.RawQuery)
+		assert.Equal(t, "/v2/homes/12345/invitations/t1", r.URL.Path)
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &DeleteInvitationInput{
+		HomeID: 12345,
+		Token:  "t1",
+	}
+
+	r, err := client.DeleteInvitation(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
+	}
+}
+
+func TestClient_GetZones(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones", r.URL.Path)
 		assert.Equal(t, http.MethodGet, r.Method)
-		_, _ = fmt.Fprint(w, `{"zoneType": "HEATING"}`)
+		_, _ = fmt.Fprint(w, `[{"id": 1}, {"id": 2}]`)
 	}
 
 	client, server := setupTestClientAndServer(f)
 	defer server.Close()
 
-	in := &GetDayReportInput{
+	in := &GetZonesInput{
+		HomeID: 12345,
+	}
+
+	z, err := client.GetZones(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotEmpty(t, z) && assert.Equal(t, 2, len(z)) {
+		assert.Equal(t, 1, z[0].ID)
+		assert.Equal(t, 2, z[1].ID)
+	}
+}
+
+func TestClient_PutZoneDetails(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/details", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"Kitchen"}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutZoneDetailsInput{
 		HomeID: 12345,
 		ZoneID: 2,
-		Date:   time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+		Name:   "Kitchen",
 	}
 
-	r, err := client.GetDayReport(in)
+	r, err := client.PutZoneDetails(in)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.True(t, called)
 	if assert.NotNil(t, r) {
-		assert.Equal(t, "HEATING", r.ZoneType)
+		assert.Empty(t, r)
 	}
 }
 
-func TestClient_PutOverlay(t *testing.T) {
+func TestClient_PutDazzleMode(t *testing.T) {
 
 	called := false
 	f := func(w http.ResponseWriter, r *http.Request) {
 		called = true
-		assert.Equal(t, "/v2/homes/12345/zones/99/overlay", r.URL.Path)
+		assert.Equal(t, "/v2/homes/12345/zones/2/dazzle", r.URL.Path)
 		assert.Equal(t, http.MethodPut, r.Method)
 		b, _ := ioutil.ReadAll(r.Body)
-		assert.Equal(t, `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":17}},"termination":{"type":"MANUAL"}}`+"\n", string(b))
-		_, _ = fmt.Fprint(w, `{"type": "MANUAL"}`)
+		assert.Equal(t, `{"enabled":true}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
 	}
 
 	client, server := setupTestClientAndServer(f)
 	defer server.Close()
 
-	in := &PutOverlayInput{
-		HomeID: 12345,
-		ZoneID: 99,
-		OverlayInput: OverlayInput{
-			Setting: OverlayInputSetting{
-				Type:  "HEATING",
-				Power: "ON",
-				Temperature: OverlayInputTemperature{
-					Celsius: 17,
-				},
-			},
-			Termination: OverlayInputTermination{
-				Type: TerminationTypeManual,
-			},
-		},
+	in := &PutDazzleModeInput{
+		HomeID:  12345,
+		ZoneID:  2,
+		Enabled: true,
 	}
 
-	o, err := client.PutOverlay(in)
+	r, err := client.PutDazzleMode(in)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.True(t, called)
-	if assert.NotNil(t, o) {
-		assert.Equal(t, "MANUAL", o.Type)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
+	}
+}
+
+func TestClient_PutZoneOrder(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zoneOrder", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `[{"id":3},{"id":1},{"id":2}]`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutZoneOrderInput{
+		HomeID:  12345,
+		ZoneIDs: []int{3, 1, 2},
+	}
+
+	r, err := client.PutZoneOrder(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
+	}
+}
+
+func TestClient_PostZoneDevice(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/devices", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"serialNo":"VA0123456789"}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PostZoneDeviceInput{
+		HomeID:   12345,
+		ZoneID:   2,
+		SerialNo: "VA0123456789",
+	}
+
+	r, err := client.PostZoneDevice(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, r) {
+		assert.Empty(t, r)
+	}
+}
+
+func TestClient_GetEarlyStart(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/earlyStart", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"enabled": true}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetEarlyStartInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	out, err := client.GetEarlyStart(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.True(t, out.Enabled)
+	}
+}
+
+func TestClient_PutEarlyStart(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/earlyStart", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"enabled":false}`+"\n", string(b))
+		_, _ = fmt.Fprint(w, `{"enabled": false}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutEarlyStartInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	out, err := client.PutEarlyStart(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.False(t, out.Enabled)
+	}
+}
+
+func TestClient_PutOpenWindowDetection(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/openWindowDetection", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"enabled":true,"timeoutInSeconds":900}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutOpenWindowDetectionInput{
+		HomeID:           12345,
+		ZoneID:           2,
+		Enabled:          true,
+		TimeoutInSeconds: 900,
+	}
+
+	out, err := client.PutOpenWindowDetection(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.Empty(t, out)
+	}
+}
+
+func TestClient_GetHomeState(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/state", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"presence": "HOME"}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetHomeStateInput{
+		HomeID: 12345,
+	}
+
+	s, err := client.GetHomeState(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, s) {
+		assert.Equal(t, TadoModeHome, s.Presence)
+		assert.Equal(t, HomeStateHome, string(s.Presence))
+	}
+}
+
+func TestClient_GetZoneState(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/state", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{
+			"tadoMode": "HOME",
+			"overlayType": "MANUAL",
+			"overlay": {"type": "MANUAL", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 21.0, "fahrenheit": 69.8}}, "termination": {"type": "TIMER", "durationInSeconds": 900}},
+			"sensorDataPoints": {"insideTemperature": {"celsius": 20.4, "fahrenheit": 68.72, "type": "TEMPERATURE"}, "humidity": {"type": "PERCENTAGE", "percentage": 55.1}}
+		}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetZoneStateInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	s, err := client.GetZoneState(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, s) {
+		assert.Equal(t, TadoModeHome, s.TadoMode)
+		assert.Equal(t, 21.0, s.Overlay.Setting.Temperature.Celsius)
+		assert.Equal(t, TerminationTypeTimer, s.Overlay.Termination.Type)
+		assert.Equal(t, 20.4, s.SensorDataPoints.InsideTemperature.Celsius)
+		assert.Equal(t, 55.1, s.SensorDataPoints.Humidity.Percentage)
+	}
+}
+
+func TestClient_GetZoneStates(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zoneStates", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"zoneStates": {"1": {"tadoMode": "HOME"}, "6": {"tadoMode": "AWAY"}}}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetZoneStatesInput{
+		HomeID: 12345,
+	}
+
+	s, err := client.GetZoneStates(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.Len(t, s, 2) {
+		assert.Equal(t, TadoModeHome, s[1].TadoMode)
+		assert.Equal(t, TadoModeAway, s[6].TadoMode)
+	}
+}
+
+func TestClient_GetZoneTemperatures(t *testing.T) {
+
+	calls := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/v2/homes/12345/zoneStates", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"zoneStates": {
+			"1": {"sensorDataPoints": {"insideTemperature": {"celsius": 20.5, "fahrenheit": 68.9}}},
+			"2": {"sensorDataPoints": {"insideTemperature": {"celsius": 17.25, "fahrenheit": 63.05}}}
+		}}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetZoneStatesInput{
+		HomeID: 12345,
+	}
+
+	temps, err := client.GetZoneTemperatures(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, map[int]Temperature{
+		1: {Celsius: 20.5, Fahrenheit: 68.9},
+		2: {Celsius: 17.25, Fahrenheit: 63.05},
+	}, temps)
+}
+
+func TestClient_GetZoneCapabilities(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/capabilities", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"type": "HEATING", "temperatures": {"celsius": {"min": 5, "max": 25, "step": 0.5}, "fahrenheit": {"min": 41, "max": 77, "step": 1}}}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetZoneCapabilitiesInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	zc, err := client.GetZoneCapabilities(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, zc) {
+		assert.Equal(t, 0.5, zc.Temperatures.Celsius.Step)
+		assert.Equal(t, 21.5, zc.Round(Temperature{Celsius: 21.4}, TemperatureUnitCelsius).Celsius)
+		assert.Equal(t, 25.0, zc.Round(Temperature{Celsius: 30}, TemperatureUnitCelsius).Celsius)
+		assert.Equal(t, 41.0, zc.Round(Temperature{Celsius: 1}, TemperatureUnitFahrenheit).Fahrenheit)
+	}
+}
+
+func TestClient_GetActiveTimetable(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/schedule/activeTimetable", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"id": 1, "type": "THREE_DAY"}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetActiveTimetableInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	out, err := client.GetActiveTimetable(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.Equal(t, 1, out.ID)
+		assert.Equal(t, TimetableTypeThreeDay, out.Type)
+	}
+}
+
+func TestClient_PutActiveTimetable(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/schedule/activeTimetable", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"id":2}`+"\n", string(b))
+		_, _ = fmt.Fprint(w, `{"id": 2, "type": "SEVEN_DAY"}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutActiveTimetableInput{
+		HomeID:    12345,
+		ZoneID:    2,
+		Timetable: Timetable{ID: 2},
+	}
+
+	out, err := client.PutActiveTimetable(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.Equal(t, TimetableTypeSevenDay, out.Type)
+	}
+}
+
+func TestClient_GetScheduleBlocks(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/schedule/timetables/0/blocks", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `[{"dayType": "MONDAY_TO_SUNDAY", "start": "00:00", "end": "07:00", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 16}}}, {"dayType": "MONDAY_TO_SUNDAY", "start": "07:00", "end": "00:00", "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 20}}}]`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetScheduleBlocksInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	out, err := client.GetScheduleBlocks(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.Len(t, out, 2) {
+		assert.Equal(t, "07:00", out[1].Start)
+		assert.Equal(t, 20.0, out[1].Setting.Temperature.Celsius)
+	}
+}
+
+func TestClient_PutScheduleBlocks(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/schedule/timetables/1/blocks/SATURDAY", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `[{"dayType":"SATURDAY","start":"00:00","end":"00:00","geolocationOverride":false,"setting":{"type":"HEATING","power":"OFF","temperature":{}}}]`+"\n", string(b))
+		_, _ = fmt.Fprint(w, `[{"dayType": "SATURDAY", "start": "00:00", "end": "00:00"}]`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutScheduleBlocksInput{
+		HomeID:      12345,
+		ZoneID:      2,
+		TimetableID: 1,
+		DayType:     "SATURDAY",
+		Blocks: []ScheduleBlock{
+			{DayType: "SATURDAY", Start: "00:00", End: "00:00", Setting: Setting{Type: ZoneTypeHeating, Power: PowerOff}},
+		},
+	}
+
+	out, err := client.PutScheduleBlocks(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	assert.Len(t, out, 1)
+}
+
+func TestClient_GetAwayConfiguration(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/schedule/awayConfiguration", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"type": "HEATING", "autoAdjust": false, "comfortLevel": 50, "setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 15}}}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetAwayConfigurationInput{
+		HomeID: 12345,
+		ZoneID: 2,
+	}
+
+	out, err := client.GetAwayConfiguration(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) && assert.NotNil(t, out.Setting) {
+		assert.Equal(t, 50, out.ComfortLevel)
+		assert.Equal(t, 15.0, out.Setting.Temperature.Celsius)
+	}
+}
+
+func TestClient_PutAwayConfiguration(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/schedule/awayConfiguration", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"type":"HEATING","autoAdjust":true,"comfortLevel":100}`+"\n", string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutAwayConfigurationInput{
+		HomeID: 12345,
+		ZoneID: 2,
+		AwayConfiguration: AwayConfiguration{
+			Type:         ZoneTypeHeating,
+			AutoAdjust:   true,
+			ComfortLevel: 100,
+		},
+	}
+
+	out, err := client.PutAwayConfiguration(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, out) {
+		assert.Empty(t, out)
+	}
+}
+
+func TestClient_GetWeather(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/weather", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"outsideTemperature": {"celsius": 8.50}}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetWeatherInput{
+		HomeID: 12345,
+	}
+
+	w, err := client.GetWeather(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, w) {
+		assert.Equal(t, 8.5, w.OutsideTemperature.Celsius)
+	}
+}
+
+func TestClient_GetDayReport(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/2/dayReport", r.URL.Path)
+		assert.Equal(t, "date=2020-12-31", r.URL.RawQuery)
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{
+			"zoneType": "HEATING",
+			"measuredData": {"insideTemperature": {"dataPoints": [{"timestamp": "2020-12-31T00:00:00.000Z", "value": {"celsius": 19.5, "fahrenheit": 67.1}}]}},
+			"settings": {"timeSeriesType": "dataIntervals", "valueType": "heatingSetting", "dataIntervals": [{"from": "2020-12-30T23:45:00.000Z", "to": "2020-12-31T06:00:00.000Z", "value": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 18.0, "fahrenheit": 64.4}}}]},
+			"callForHeat": {"dataIntervals": [{"from": "2020-12-31T06:00:00.000Z", "to": "2020-12-31T07:30:00.000Z", "value": "HIGH"}]},
+			"weather": {"slots": {"slots": {"08:00": {"state": "CLOUDY", "temperature": {"celsius": 3.2, "fahrenheit": 37.8}}}}}
+		}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &GetDayReportInput{
+		HomeID: 12345,
+		ZoneID: 2,
+		Date:   time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	r, err := client.GetDayReport(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, r) {
+		assert.Equal(t, ZoneTypeHeating, r.ZoneType)
+		if assert.Len(t, r.MeasuredData.InsideTemperature.DataPoints, 1) {
+			assert.Equal(t, 19.5, r.MeasuredData.InsideTemperature.DataPoints[0].Value.Celsius)
+		}
+		assert.Equal(t, "heatingSetting", r.Settings.ValueType)
+		if assert.Len(t, r.Settings.DataIntervals, 1) {
+			assert.Equal(t, 18.0, r.Settings.DataIntervals[0].Value.Temperature.Celsius)
+			assert.Equal(t, 6*time.Hour+15*time.Minute, r.Settings.DataIntervals[0].Duration())
+		}
+		if assert.Len(t, r.CallForHeat.DataIntervals, 1) {
+			assert.Equal(t, CallForHeatHigh, r.CallForHeat.DataIntervals[0].Value)
+		}
+		assert.Equal(t, "CLOUDY", r.Weather.Slots.Slots["08:00"].State)
+	}
+}
+
+func TestClient_PutOverlay(t *testing.T) {
+
+	called := false
+	f := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/v2/homes/12345/zones/99/overlay", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"setting":{"type":"HEATING","power":"ON","temperature":{"celsius":17}},"termination":{"type":"MANUAL"}}`+"\n", string(b))
+		_, _ = fmt.Fprint(w, `{"type": "MANUAL"}`)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	in := &PutOverlayInput{
+		HomeID: 12345,
+		ZoneID: 99,
+		OverlayInput: OverlayInput{
+			Setting: OverlayInputSetting{
+				Type:  "HEATING",
+				Power: "ON",
+				Temperature: OverlayInputTemperature{
+					Celsius: 17,
+				},
+			},
+			Termination: OverlayInputTermination{
+				Type: TerminationTypeManual,
+			},
+		},
+	}
+
+	o, err := client.PutOverlay(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, called)
+	if assert.NotNil(t, o) {
+		assert.Equal(t, OverlayTypeManual, o.Type)
+	}
+
+	// overlays that can never be valid are not sent
+	called = false
+	in.OverlayInput.Termination = OverlayInputTermination{Type: TerminationTypeTimer}
+	o, err = client.PutOverlay(in)
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid overlay: termination type TIMER requires a duration", err.Error())
 	}
+	assert.Nil(t, o)
+	assert.False(t, called)
 }
 
 func TestClient_DeleteOverlay(t *testing.T) {
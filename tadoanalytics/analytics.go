@@ -0,0 +1,218 @@
+// Package tadoanalytics computes heating statistics from Tado day reports.
+package tadoanalytics
+
+import (
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+)
+
+const (
+	// DefaultTolerance is the default tolerance in °C around the setpoint
+	DefaultTolerance = 0.5
+	// DefaultBaseTemperature is the default base temperature in °C for heating degree-hours
+	DefaultBaseTemperature = 15.5
+	// DefaultMaxSampleGap is the default maximum time a temperature measurement is assumed to hold
+	DefaultMaxSampleGap = 30 * time.Minute
+)
+
+// Options configures the analysis, use DefaultOptions to start from the defaults.
+// The zero Options uses the defaults as well, in other options fields that cannot be used get their default.
+type Options struct {
+	// Tolerance is the difference in °C from the setpoint that still counts as reaching it, 0 requires the exact setpoint
+	Tolerance float64
+	// BaseTemperature is the outside temperature in °C below which a home needs heating
+	BaseTemperature float64
+	// MaxSampleGap is the maximum time a temperature measurement is assumed to hold until the next one,
+	// longer gaps are treated as missing data. It defaults to DefaultMaxSampleGap when it is not positive.
+	MaxSampleGap time.Duration
+}
+
+// DefaultOptions returns the default options.
+func DefaultOptions() Options {
+	return Options{
+		Tolerance:       DefaultTolerance,
+		BaseTemperature: DefaultBaseTemperature,
+		MaxSampleGap:    DefaultMaxSampleGap,
+	}
+}
+
+// withDefaults returns the default options for the zero Options, and the defaults for values that cannot be used
+func (o Options) withDefaults() Options {
+	if o == (Options{}) {
+		return DefaultOptions()
+	}
+	if o.Tolerance < 0 {
+		o.Tolerance = 0
+	}
+	if o.MaxSampleGap <= 0 {
+		o.MaxSampleGap = DefaultMaxSampleGap
+	}
+	return o
+}
+
+// DayStats contains the statistics of a zone for one day report.
+type DayStats struct {
+	Interval tado.Interval
+	Heating  HeatingStats
+	Setpoint SetpointStats
+	// AverageHumidity is the average relative humidity as fraction between 0 and 1, 0 when there is no data
+	AverageHumidity float64
+	// HumiditySamples is the number of humidity measurements
+	HumiditySamples int
+	// HeatingDegreeHours is the sum of the outside temperature below the base temperature over time, in °C·h
+	HeatingDegreeHours float64
+}
+
+// HeatingStats contains the heating duty cycle
+type HeatingStats struct {
+	// Total is the time covered by call for heat data
+	Total time.Duration
+	// Heating is the time the zone called for heat
+	Heating time.Duration
+	// ByLevel is the time per call for heat value
+	ByLevel map[tado.CallForHeat]time.Duration
+}
+
+// DutyCycle returns the fraction of time the zone called for heat, 0 when there is no data.
+func (hs HeatingStats) DutyCycle() float64 {
+	if hs.Total <= 0 {
+		return 0
+	}
+	return float64(hs.Heating) / float64(hs.Total)
+}
+
+// SetpointStats contains the time the inside temperature was below, within or above the setpoint.
+// Time when the heating was off or when no temperature was measured is not counted.
+type SetpointStats struct {
+	Below  time.Duration
+	Within time.Duration
+	Above  time.Duration
+}
+
+// Total returns the time with both a setpoint and a measured temperature.
+func (ss SetpointStats) Total() time.Duration {
+	return ss.Below + ss.Within + ss.Above
+}
+
+// FractionBelow returns the fraction of time the temperature was below the setpoint, 0 when there is no data.
+func (ss SetpointStats) FractionBelow() float64 {
+	if ss.Total() <= 0 {
+		return 0
+	}
+	return float64(ss.Below) / float64(ss.Total())
+}
+
+// Analyze computes the statistics of a day report. It can also be used for the merged report of
+// GetReportRange, the statistics then cover the whole range.
+func Analyze(dr *tado.DayReport, opts Options) DayStats {
+	opts = opts.withDefaults()
+	ds := DayStats{
+		Interval:           dr.Interval,
+		Heating:            Heating(dr.CallForHeat),
+		Setpoint:           Setpoint(dr.MeasuredData.InsideTemperature, dr.Settings, opts.Tolerance, opts.MaxSampleGap),
+		HeatingDegreeHours: HeatingDegreeHours(dr.Weather.Condition, opts.BaseTemperature),
+	}
+	ds.AverageHumidity, ds.HumiditySamples = AverageHumidity(dr.MeasuredData.Humidity)
+	return ds
+}
+
+// AnalyzeDays computes the statistics of every day report.
+func AnalyzeDays(reports []tado.DayReport, opts Options) []DayStats {
+	stats := make([]DayStats, len(reports))
+	for i := range reports {
+		stats[i] = Analyze(&reports[i], opts)
+	}
+	return stats
+}
+
+// Heating returns the heating duty cycle from the call for heat intervals.
+func Heating(callForHeat tado.IntervalSeries[tado.CallForHeat]) HeatingStats {
+	hs := HeatingStats{ByLevel: make(map[tado.CallForHeat]time.Duration)}
+	for _, di := range callForHeat.DataIntervals {
+		d := di.Duration()
+		if d <= 0 {
+			continue
+		}
+		hs.Total += d
+		hs.ByLevel[di.Value] += d
+		if di.Value != tado.CallForHeatNone && di.Value != "" {
+			hs.Heating += d
+		}
+	}
+	return hs
+}
+
+// Setpoint returns the time the inside temperature was below, within or above the setpoint.
+// Every measurement holds until the next one, for at most maxGap.
+func Setpoint(temps tado.PointSeries[tado.Temperature], settings tado.IntervalSeries[tado.Setting], tolerance float64, maxGap time.Duration) SetpointStats {
+	var ss SetpointStats
+	points := temps.DataPoints
+	for i, dp := range points {
+		from := dp.Timestamp
+		to := from.Add(maxGap)
+		if i+1 < len(points) && points[i+1].Timestamp.Before(to) {
+			to = points[i+1].Timestamp
+		}
+		temperature := dp.Value.Value(tado.TemperatureUnitCelsius)
+
+		for _, si := range settings.DataIntervals {
+			if si.Value.Power != tado.PowerOn {
+				continue
+			}
+			start, end := maxTime(from, si.From), minTime(to, si.To)
+			if !end.After(start) {
+				continue
+			}
+			d := end.Sub(start)
+			setpoint := si.Value.Temperature.Value(tado.TemperatureUnitCelsius)
+			switch {
+			case temperature < setpoint-tolerance:
+				ss.Below += d
+			case temperature > setpoint+tolerance:
+				ss.Above += d
+			default:
+				ss.Within += d
+			}
+		}
+	}
+	return ss
+}
+
+// AverageHumidity returns the average of the humidity measurements and the number of measurements.
+func AverageHumidity(h tado.HumiditySeries) (float64, int) {
+	if len(h.DataPoints) == 0 {
+		return 0, 0
+	}
+	sum := 0.0
+	for _, dp := range h.DataPoints {
+		sum += dp.Value
+	}
+	return sum / float64(len(h.DataPoints)), len(h.DataPoints)
+}
+
+// HeatingDegreeHours returns the sum of the outside temperature below base over time, in °C·h.
+func HeatingDegreeHours(conditions tado.IntervalSeries[tado.WeatherCondition], base float64) float64 {
+	dh := 0.0
+	for _, di := range conditions.DataIntervals {
+		below := base - di.Value.Temperature.Value(tado.TemperatureUnitCelsius)
+		if below > 0 && di.Duration() > 0 {
+			dh += below * di.Duration().Hours()
+		}
+	}
+	return dh
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,112 @@
+package tadoanalytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+	"github.com/stretchr/testify/assert"
+)
+
+var day = time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func celsius(c float64) tado.Temperature {
+	return tado.NewTemperature(c, tado.TemperatureUnitCelsius)
+}
+
+func testDayReport() *tado.DayReport {
+	dr := &tado.DayReport{Interval: tado.Interval{From: at(0, 0), To: at(24, 0)}}
+	dr.CallForHeat.DataIntervals = []tado.DataInterval[tado.CallForHeat]{
+		{From: at(0, 0), To: at(6, 0), Value: tado.CallForHeatNone},
+		{From: at(6, 0), To: at(7, 0), Value: tado.CallForHeatHigh},
+		{From: at(7, 0), To: at(9, 0), Value: tado.CallForHeatLow},
+		{From: at(9, 0), To: at(24, 0), Value: tado.CallForHeatNone},
+	}
+	dr.Settings.DataIntervals = []tado.DataInterval[tado.Setting]{
+		{From: at(0, 0), To: at(6, 0), Value: tado.Setting{Type: tado.ZoneTypeHeating, Power: tado.PowerOff}},
+		{From: at(6, 0), To: at(24, 0), Value: tado.Setting{Type: tado.ZoneTypeHeating, Power: tado.PowerOn, Temperature: celsius(20)}},
+	}
+	dr.MeasuredData.InsideTemperature.DataPoints = []tado.DataPoint[tado.Temperature]{
+		{Timestamp: at(5, 45), Value: celsius(16)},
+		{Timestamp: at(6, 15), Value: celsius(18)},
+		{Timestamp: at(6, 45), Value: celsius(19.8)},
+		{Timestamp: at(7, 15), Value: celsius(21)},
+		// no measurements between 7:45 and 8:00
+	}
+	dr.MeasuredData.Humidity.DataPoints = []tado.DataPoint[float64]{
+		{Timestamp: at(6, 0), Value: 0.5},
+		{Timestamp: at(12, 0), Value: 0.6},
+	}
+	dr.Weather.Condition.DataIntervals = []tado.DataInterval[tado.WeatherCondition]{
+		{From: at(0, 0), To: at(12, 0), Value: tado.WeatherCondition{State: "CLOUDY", Temperature: celsius(5.5)}},
+		{From: at(12, 0), To: at(24, 0), Value: tado.WeatherCondition{State: "SUN", Temperature: celsius(20)}},
+	}
+	return dr
+}
+
+func TestAnalyze(t *testing.T) {
+	ds := Analyze(testDayReport(), DefaultOptions())
+
+	assert.Equal(t, 24*time.Hour, ds.Interval.Duration())
+
+	assert.Equal(t, 24*time.Hour, ds.Heating.Total)
+	assert.Equal(t, 3*time.Hour, ds.Heating.Heating)
+	assert.Equal(t, time.Hour, ds.Heating.ByLevel[tado.CallForHeatHigh])
+	assert.Equal(t, 2*time.Hour, ds.Heating.ByLevel[tado.CallForHeatLow])
+	assert.Equal(t, 21*time.Hour, ds.Heating.ByLevel[tado.CallForHeatNone])
+	assert.Equal(t, 0.125, ds.Heating.DutyCycle())
+
+	// 6:00-6:45 below, 6:45-7:15 within, 7:15-7:45 above, the heating was off before 6:00
+	assert.Equal(t, 45*time.Minute, ds.Setpoint.Below)
+	assert.Equal(t, 30*time.Minute, ds.Setpoint.Within)
+	assert.Equal(t, 30*time.Minute, ds.Setpoint.Above)
+	assert.Equal(t, 105*time.Minute, ds.Setpoint.Total())
+	assert.InDelta(t, 45.0/105, ds.Setpoint.FractionBelow(), 1e-9)
+
+	assert.InDelta(t, 0.55, ds.AverageHumidity, 1e-9)
+	assert.Equal(t, 2, ds.HumiditySamples)
+
+	// (15.5 - 5.5) * 12h, it was warmer than the base temperature in the afternoon
+	assert.InDelta(t, 120, ds.HeatingDegreeHours, 1e-9)
+}
+
+func TestAnalyze_Options(t *testing.T) {
+	ds := Analyze(testDayReport(), Options{Tolerance: 2, BaseTemperature: 10, MaxSampleGap: time.Hour})
+
+	// 6:00-6:15 below, the last measurement holds until 8:15
+	assert.Equal(t, 15*time.Minute, ds.Setpoint.Below)
+	assert.Equal(t, 2*time.Hour, ds.Setpoint.Within)
+	assert.Equal(t, time.Duration(0), ds.Setpoint.Above)
+	assert.InDelta(t, 54, ds.HeatingDegreeHours, 1e-9)
+
+	// the zero options use the defaults
+	assert.Equal(t, Analyze(testDayReport(), DefaultOptions()), Analyze(testDayReport(), Options{}))
+
+	// zero is a valid tolerance and base temperature in other options, the sample gap uses the default
+	ds = Analyze(testDayReport(), Options{MaxSampleGap: -time.Minute})
+	assert.Equal(t, 75*time.Minute, ds.Setpoint.Below)
+	assert.Equal(t, time.Duration(0), ds.Setpoint.Within)
+	assert.Equal(t, 30*time.Minute, ds.Setpoint.Above)
+	assert.Equal(t, 0.0, ds.HeatingDegreeHours)
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	ds := Analyze(new(tado.DayReport), Options{})
+	assert.Equal(t, 0.0, ds.Heating.DutyCycle())
+	assert.Equal(t, 0.0, ds.Setpoint.FractionBelow())
+	assert.Equal(t, 0.0, ds.AverageHumidity)
+	assert.Equal(t, 0, ds.HumiditySamples)
+	assert.Equal(t, 0.0, ds.HeatingDegreeHours)
+}
+
+func TestAnalyzeDays(t *testing.T) {
+	stats := AnalyzeDays([]tado.DayReport{*testDayReport(), {}}, DefaultOptions())
+	if assert.Len(t, stats, 2) {
+		assert.Equal(t, 3*time.Hour, stats[0].Heating.Heating)
+		assert.Equal(t, time.Duration(0), stats[1].Heating.Heating)
+	}
+}
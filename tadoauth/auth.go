@@ -1,12 +1,16 @@
 package tadoauth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"regexp"
+	"time"
 )
 
 const (
@@ -21,6 +25,9 @@ var endpoint = defaultEndpoint
 // Client is the main client used to communicate with the Tado authentication API.
 type Client struct {
 	HTTPClient *http.Client
+	// Logger logs token requests when it is set, request and response bodies are logged at debug level.
+	// Passwords, secrets and tokens are never logged.
+	Logger *slog.Logger
 }
 
 // NewClient is the constructor for the authentication client.
@@ -36,13 +43,23 @@ func (c *Client) do(data url.Values) (*TokenResponse, error) {
 	data.Set("client_secret", clientSecret)
 	data.Set("scope", scope)
 
+	ctx := context.Background()
+	start := time.Now()
+	c.log(ctx, slog.LevelDebug, "tado auth request", slog.String("grantType", data.Get("grant_type")),
+		slog.String("body", RedactForm(data).Encode()))
+
 	// post form
 	resp, err := c.HTTPClient.PostForm(endpoint, data)
 	if err != nil {
+		c.log(ctx, slog.LevelError, "tado auth request failed", slog.String("grantType", data.Get("grant_type")),
+			slog.Duration("duration", time.Since(start)), slog.String("error", err.Error()))
 		return nil, fmt.Errorf("authentication HTTP error: %s", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	c.log(ctx, slog.LevelInfo, "tado auth response", slog.String("grantType", data.Get("grant_type")),
+		slog.Int("status", resp.StatusCode), slog.Duration("duration", time.Since(start)))
+
 	// check HTTP status
 	if resp.StatusCode >= http.StatusBadRequest {
 		// not OK, read body
@@ -50,6 +67,7 @@ func (c *Client) do(data url.Values) (*TokenResponse, error) {
 		if err != nil {
 			return nil, fmt.Errorf("authentication error: %s", err)
 		}
+		c.log(ctx, slog.LevelDebug, "tado auth response body", slog.String("body", RedactJSON(string(body))))
 		// check if it is a JSON error response
 		ae := new(AuthenticationError)
 		err = json.Unmarshal(body, ae)
@@ -62,15 +80,58 @@ func (c *Client) do(data url.Values) (*TokenResponse, error) {
 	}
 
 	// HTTP status is OK or similar
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("authentication HTTP error: %s", err)
+	}
+	c.log(ctx, slog.LevelDebug, "tado auth response body", slog.String("body", RedactJSON(string(body))))
 	tr := new(TokenResponse)
-	err = json.NewDecoder(resp.Body).Decode(tr)
+	err = json.Unmarshal(body, tr)
 	if err != nil {
 		return nil, fmt.Errorf("authentication JSON error: %s", err)
 	}
 
+	c.log(ctx, slog.LevelDebug, "tado auth token received", slog.String("tokenType", tr.TokenType),
+		slog.Int("expiresIn", tr.ExpiresIn), slog.String("scope", tr.Scope), slog.String("jti", tr.Jti))
+
 	return tr, nil
 }
 
+// log logs to the logger of the client if it is set
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.Logger == nil {
+		return
+	}
+	c.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// Redacted replaces secrets in logs.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the form and JSON keys that are redacted
+var sensitiveKeys = []string{"password", "client_secret", "access_token", "refresh_token", "id_token"}
+
+var sensitiveJSONRegexp = regexp.MustCompile(`("(?:password|client_secret|access_token|refresh_token|id_token)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// RedactForm returns a copy of data with passwords, secrets and tokens redacted.
+func RedactForm(data url.Values) url.Values {
+	r := make(url.Values, len(data))
+	for k, v := range data {
+		r[k] = v
+	}
+	for _, k := range sensitiveKeys {
+		if _, ok := r[k]; ok {
+			r[k] = []string{Redacted}
+		}
+	}
+	return r
+}
+
+// RedactJSON returns s with the values of password, secret and token fields redacted.
+func RedactJSON(s string) string {
+	return sensitiveJSONRegexp.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+}
+
 // GetToken returns a new authentication and refresh token for a user
 func (c *Client) GetToken(username, password string) (*TokenResponse, error) {
 	// set form data
@@ -1,10 +1,13 @@
 package tadoauth
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -131,3 +134,45 @@ func TestClient_TestRefreshToken(t *testing.T) {
 		assert.Equal(t, "t0ken", tokenResponse.AccessToken)
 	}
 }
+
+func TestClient_Logger(t *testing.T) {
+	c := NewClient()
+	buf := new(bytes.Buffer)
+	c.Logger = slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"access_token": "acc3ss", "refresh_token": "refr3sh", "token_type": "bearer", "expires_in": 599}`)
+	}))
+	defer testServer.Close()
+	endpoint = testServer.URL
+	defer func() { endpoint = defaultEndpoint }()
+
+	_, err := c.GetToken("fake@sample.com", "PassW0rd")
+	assert.NoError(t, err)
+	_, err = c.RefreshToken("refr3sh")
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.NotContains(t, out, "PassW0rd")
+	assert.NotContains(t, out, "acc3ss")
+	assert.NotContains(t, out, "refr3sh")
+	assert.NotContains(t, out, clientSecret)
+	assert.Contains(t, out, `level=INFO msg="tado auth response" grantType=password status=200`)
+	assert.Contains(t, out, `level=INFO msg="tado auth response" grantType=refresh_token status=200`)
+	assert.Contains(t, out, `level=DEBUG msg="tado auth token received" tokenType=bearer expiresIn=599`)
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "client_secret=%5BREDACTED%5D&password=%5BREDACTED%5D&username=me",
+		RedactForm(url.Values{"username": {"me"}, "password": {"pw"}, "client_secret": {"s"}}).Encode())
+	assert.Equal(t, `{"access_token": "[REDACTED]","refresh_token":"[REDACTED]","scope":"home.user"}`,
+		RedactJSON(`{"access_token": "a\"b","refresh_token":"c","scope":"home.user"}`))
+}
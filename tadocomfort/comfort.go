@@ -0,0 +1,190 @@
+// Package tadocomfort computes comfort scores and mould risk from the temperature and humidity measured by Tado.
+package tadocomfort
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+)
+
+// Magnus formula coefficients over water, valid between -45°C and 60°C
+const (
+	magnusA = 17.62
+	magnusB = 243.12
+)
+
+// Thresholds configures the comfort and mould-risk assessment, use DefaultThresholds to start from the defaults.
+// The zero Thresholds uses the defaults as well, in other thresholds fields that cannot be used get their default.
+// Humidity values are relative humidity in percent, temperatures are in °C.
+type Thresholds struct {
+	// MinTemperature and MaxTemperature are the comfortable inside temperature range,
+	// the default range is used when both are 0
+	MinTemperature float64
+	MaxTemperature float64
+	// MinHumidity and MaxHumidity are the comfortable relative humidity range,
+	// the default range is used when both are 0
+	MinHumidity float64
+	MaxHumidity float64
+	// MouldSurfaceHumidity is the relative humidity at the coldest surface above which mould can grow,
+	// it defaults to 80 when it is not positive
+	MouldSurfaceHumidity float64
+	// MouldMinDuration is the time the surface humidity must stay above MouldSurfaceHumidity to be reported
+	MouldMinDuration time.Duration
+	// TemperatureFactor is the temperature factor (fRsi) of the coldest surface, used to estimate its temperature
+	// from the inside and outside temperature: surface = outside + TemperatureFactor * (inside - outside).
+	// It defaults to 0.7 when it is not positive.
+	TemperatureFactor float64
+	// MaxSampleGap is the maximum time a measurement is assumed to hold until the next one,
+	// longer gaps are treated as missing data. It defaults to 30 minutes when it is not positive.
+	MaxSampleGap time.Duration
+}
+
+// DefaultThresholds returns the default thresholds.
+// The mould thresholds follow the common building guideline of 80% relative humidity at a surface with
+// a temperature factor of 0.7.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		MinTemperature:       19,
+		MaxTemperature:       24,
+		MinHumidity:          40,
+		MaxHumidity:          60,
+		MouldSurfaceHumidity: 80,
+		MouldMinDuration:     3 * time.Hour,
+		TemperatureFactor:    0.7,
+		MaxSampleGap:         30 * time.Minute,
+	}
+}
+
+// withDefaults returns the thresholds with the defaults for values that cannot be used
+func (th Thresholds) withDefaults() Thresholds {
+	d := DefaultThresholds()
+	if th == (Thresholds{}) {
+		return d
+	}
+	if th.MinTemperature == 0 && th.MaxTemperature == 0 {
+		th.MinTemperature, th.MaxTemperature = d.MinTemperature, d.MaxTemperature
+	}
+	if th.MinHumidity == 0 && th.MaxHumidity == 0 {
+		th.MinHumidity, th.MaxHumidity = d.MinHumidity, d.MaxHumidity
+	}
+	if th.MouldSurfaceHumidity <= 0 {
+		th.MouldSurfaceHumidity = d.MouldSurfaceHumidity
+	}
+	if th.TemperatureFactor <= 0 {
+		th.TemperatureFactor = d.TemperatureFactor
+	}
+	if th.MaxSampleGap <= 0 {
+		th.MaxSampleGap = d.MaxSampleGap
+	}
+	return th
+}
+
+// DewPoint returns the dew point in °C for a temperature in °C and a relative humidity in percent,
+// using the Magnus formula. It returns NaN if the humidity is not positive.
+func DewPoint(temperature, humidity float64) float64 {
+	if humidity <= 0 {
+		return math.NaN()
+	}
+	gamma := math.Log(humidity/100) + magnusA*temperature/(magnusB+temperature)
+	return magnusB * gamma / (magnusA - gamma)
+}
+
+// RelativeHumidity returns the relative humidity in percent of air with the given dew point at a temperature,
+// capped at 100.
+func RelativeHumidity(temperature, dewPoint float64) float64 {
+	rh := 100 * saturationPressure(dewPoint) / saturationPressure(temperature)
+	return math.Min(rh, 100)
+}
+
+// saturationPressure returns the saturation vapour pressure in hPa at a temperature in °C
+func saturationPressure(temperature float64) float64 {
+	return 6.112 * math.Exp(magnusA*temperature/(magnusB+temperature))
+}
+
+// Reading is a combined temperature and humidity measurement.
+type Reading struct {
+	Time time.Time
+	// Temperature is the inside temperature in °C
+	Temperature float64
+	// Humidity is the relative humidity in percent
+	Humidity float64
+	// Outside is the outside temperature in °C, nil when unknown
+	Outside *float64
+}
+
+// DewPoint returns the dew point of the reading in °C.
+func (r Reading) DewPoint() float64 {
+	return DewPoint(r.Temperature, r.Humidity)
+}
+
+// SurfaceTemperature returns the estimated temperature in °C of the coldest surface using the temperature factor.
+// When the outside temperature is unknown the inside temperature is returned.
+func (r Reading) SurfaceTemperature(temperatureFactor float64) float64 {
+	if r.Outside == nil {
+		return r.Temperature
+	}
+	return *r.Outside + temperatureFactor*(r.Temperature-*r.Outside)
+}
+
+// SurfaceHumidity returns the estimated relative humidity in percent at the coldest surface.
+func (r Reading) SurfaceHumidity(temperatureFactor float64) float64 {
+	return RelativeHumidity(r.SurfaceTemperature(temperatureFactor), r.DewPoint())
+}
+
+// ZoneStateReading returns the latest reading of a zone state, outside is the outside temperature in °C or nil.
+// It returns false if the zone state does not contain both a temperature and a humidity measurement.
+func ZoneStateReading(zs *tado.ZoneState, outside *float64) (Reading, bool) {
+	t, h := zs.SensorDataPoints.InsideTemperature, zs.SensorDataPoints.Humidity
+	if t.Timestamp.IsZero() || h.Timestamp.IsZero() {
+		return Reading{}, false
+	}
+	r := Reading{
+		Time:        h.Timestamp,
+		Temperature: t.Value(tado.TemperatureUnitCelsius),
+		Humidity:    h.Percentage,
+		Outside:     outside,
+	}
+	if t.Timestamp.After(r.Time) {
+		r.Time = t.Timestamp
+	}
+	return r, true
+}
+
+// DayReportReadings returns the readings of a day report. Every humidity measurement is combined with the latest
+// inside temperature measured at most maxGap before it, and with the outside temperature of the weather at that time.
+// A maxGap that is not positive uses the default of DefaultThresholds.
+func DayReportReadings(dr *tado.DayReport, maxGap time.Duration) []Reading {
+	if maxGap <= 0 {
+		maxGap = DefaultThresholds().MaxSampleGap
+	}
+	temps := dr.MeasuredData.InsideTemperature.DataPoints
+	conditions := dr.Weather.Condition.DataIntervals
+
+	var readings []Reading
+	for _, hp := range dr.MeasuredData.Humidity.DataPoints {
+		// latest temperature at or before the humidity measurement
+		i := sort.Search(len(temps), func(i int) bool {
+			return temps[i].Timestamp.After(hp.Timestamp)
+		}) - 1
+		if i < 0 || hp.Timestamp.Sub(temps[i].Timestamp) > maxGap {
+			continue
+		}
+		r := Reading{
+			Time:        hp.Timestamp,
+			Temperature: temps[i].Value.Value(tado.TemperatureUnitCelsius),
+			// day reports contain the humidity as a fraction
+			Humidity: hp.Value * 100,
+		}
+		for _, c := range conditions {
+			if !hp.Timestamp.Before(c.From) && hp.Timestamp.Before(c.To) {
+				outside := c.Value.Temperature.Value(tado.TemperatureUnitCelsius)
+				r.Outside = &outside
+				break
+			}
+		}
+		readings = append(readings, r)
+	}
+	return readings
+}
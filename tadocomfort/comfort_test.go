@@ -0,0 +1,99 @@
+package tadocomfort
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDewPoint(t *testing.T) {
+	assert.InDelta(t, 9.26, DewPoint(20, 50), 0.01)
+	assert.InDelta(t, 20, DewPoint(20, 100), 1e-9)
+	assert.InDelta(t, 0.04, DewPoint(10, 50), 0.01)
+	assert.True(t, math.IsNaN(DewPoint(20, 0)))
+
+	assert.InDelta(t, 50, RelativeHumidity(20, DewPoint(20, 50)), 1e-9)
+	assert.Equal(t, 100.0, RelativeHumidity(10, 15))
+}
+
+func TestReading(t *testing.T) {
+	outside := 0.0
+	r := Reading{Temperature: 20, Humidity: 60, Outside: &outside}
+	assert.InDelta(t, 14, r.SurfaceTemperature(0.7), 1e-9)
+	assert.InDelta(t, 87.7, r.SurfaceHumidity(0.7), 0.1)
+
+	r.Outside = nil
+	assert.Equal(t, 20.0, r.SurfaceTemperature(0.7))
+	assert.InDelta(t, 60, r.SurfaceHumidity(0.7), 1e-9)
+}
+
+func TestZoneStateReading(t *testing.T) {
+	ts := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+	zs := new(tado.ZoneState)
+	_, ok := ZoneStateReading(zs, nil)
+	assert.False(t, ok)
+
+	zs.SensorDataPoints.InsideTemperature = tado.TemperatureDataPoint{Temperature: tado.NewTemperature(68, tado.TemperatureUnitFahrenheit), Timestamp: ts}
+	zs.SensorDataPoints.Humidity = tado.PercentageDataPoint{Percentage: 55.5, Timestamp: ts.Add(-time.Minute)}
+	r, ok := ZoneStateReading(zs, nil)
+	if assert.True(t, ok) {
+		assert.Equal(t, ts, r.Time)
+		assert.InDelta(t, 20, r.Temperature, 1e-9)
+		assert.Equal(t, 55.5, r.Humidity)
+		assert.Nil(t, r.Outside)
+	}
+
+	a := Assess(r, DefaultThresholds())
+	assert.True(t, a.Comfortable())
+	assert.False(t, a.MouldRisk)
+}
+
+// testDayReport returns a day report with measurements every 15 minutes and an outside temperature of 0°C
+func testDayReport() *tado.DayReport {
+	day := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	dr := &tado.DayReport{Interval: tado.Interval{From: day, To: day.Add(24 * time.Hour)}}
+	dr.Weather.Condition.DataIntervals = []tado.DataInterval[tado.WeatherCondition]{
+		{From: day, To: day.Add(24 * time.Hour), Value: tado.WeatherCondition{Temperature: tado.NewTemperature(0, tado.TemperatureUnitCelsius)}},
+	}
+
+	for ts := day; ts.Before(dr.Interval.To); ts = ts.Add(15 * time.Minute) {
+		h := ts.Sub(day)
+
+		temperature := 21.0
+		if h < 8*time.Hour {
+			temperature = 18
+		}
+		dr.MeasuredData.InsideTemperature.DataPoints = append(dr.MeasuredData.InsideTemperature.DataPoints,
+			tado.DataPoint[tado.Temperature]{Timestamp: ts, Value: tado.NewTemperature(temperature, tado.TemperatureUnitCelsius)})
+
+		humidity := 0.5
+		switch {
+		case h < 6*time.Hour:
+			humidity = 0.75
+		case h >= 12*time.Hour && h < 13*time.Hour:
+			// no humidity measured
+			continue
+		case h >= 14*time.Hour && h < 14*time.Hour+30*time.Minute:
+			// a shower
+			humidity = 0.8
+		}
+		dr.MeasuredData.Humidity.DataPoints = append(dr.MeasuredData.Humidity.DataPoints,
+			tado.DataPoint[float64]{Timestamp: ts, Value: humidity})
+	}
+	return dr
+}
+
+func TestDayReportReadings(t *testing.T) {
+	readings := DayReportReadings(testDayReport(), 30*time.Minute)
+	if assert.Len(t, readings, 92) {
+		r := readings[0]
+		assert.Equal(t, 18.0, r.Temperature)
+		assert.Equal(t, 75.0, r.Humidity)
+		if assert.NotNil(t, r.Outside) {
+			assert.Equal(t, 0.0, *r.Outside)
+		}
+	}
+}
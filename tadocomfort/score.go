@@ -0,0 +1,164 @@
+package tadocomfort
+
+import (
+	"math"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+)
+
+// Assessment is the comfort and mould risk of a single reading.
+type Assessment struct {
+	Reading
+	DewPoint           float64
+	SurfaceTemperature float64
+	SurfaceHumidity    float64
+	TooCold            bool
+	TooWarm            bool
+	TooDry             bool
+	TooHumid           bool
+	MouldRisk          bool
+}
+
+// Comfortable returns true if both the temperature and the humidity are within the comfortable range.
+func (a Assessment) Comfortable() bool {
+	return !a.TooCold && !a.TooWarm && !a.TooDry && !a.TooHumid
+}
+
+// Assess returns the comfort and mould risk of a reading.
+func Assess(r Reading, th Thresholds) Assessment {
+	th = th.withDefaults()
+	a := Assessment{
+		Reading:            r,
+		DewPoint:           r.DewPoint(),
+		SurfaceTemperature: r.SurfaceTemperature(th.TemperatureFactor),
+		TooCold:            r.Temperature < th.MinTemperature,
+		TooWarm:            r.Temperature > th.MaxTemperature,
+		TooDry:             r.Humidity < th.MinHumidity,
+		TooHumid:           r.Humidity > th.MaxHumidity,
+	}
+	a.SurfaceHumidity = RelativeHumidity(a.SurfaceTemperature, a.DewPoint)
+	a.MouldRisk = a.SurfaceHumidity >= th.MouldSurfaceHumidity
+	return a
+}
+
+// RiskPeriod is a period with a sustained mould risk.
+type RiskPeriod struct {
+	From time.Time
+	To   time.Time
+	// MaxSurfaceHumidity is the highest estimated surface humidity in percent during the period
+	MaxSurfaceHumidity float64
+	// MinSurfaceTemperature is the lowest estimated surface temperature in °C during the period
+	MinSurfaceTemperature float64
+}
+
+// Duration returns the length of the risk period.
+func (rp RiskPeriod) Duration() time.Duration {
+	return rp.To.Sub(rp.From)
+}
+
+// DayComfort contains the comfort and mould risk of a zone for one day report.
+type DayComfort struct {
+	Interval tado.Interval
+	// Score is the percentage of the measured time that was comfortable, between 0 and 100
+	Score float64
+	// Measured is the time with both a temperature and a humidity measurement
+	Measured    time.Duration
+	Comfortable time.Duration
+	TooCold     time.Duration
+	TooWarm     time.Duration
+	TooDry      time.Duration
+	TooHumid    time.Duration
+	// MaxDewPoint is the highest dew point in °C, NaN when nothing was measured
+	MaxDewPoint float64
+	// MouldRisk contains the periods with a mould risk lasting at least Thresholds.MouldMinDuration
+	MouldRisk []RiskPeriod
+}
+
+// HasMouldRisk returns true if the day contains at least one mould-risk period.
+func (dc DayComfort) HasMouldRisk() bool {
+	return len(dc.MouldRisk) > 0
+}
+
+// Analyze computes the comfort and mould risk of a day report. It can also be used for the merged report of
+// GetReportRange, mould-risk periods then can span multiple days.
+func Analyze(dr *tado.DayReport, th Thresholds) DayComfort {
+	th = th.withDefaults()
+	dc := DayComfort{Interval: dr.Interval, MaxDewPoint: math.NaN()}
+
+	readings := DayReportReadings(dr, th.MaxSampleGap)
+	var risk *RiskPeriod
+	for i, r := range readings {
+		// every reading holds until the next one, for at most MaxSampleGap
+		to := r.Time.Add(th.MaxSampleGap)
+		if i+1 < len(readings) && readings[i+1].Time.Before(to) {
+			to = readings[i+1].Time
+		}
+		if !dr.Interval.To.IsZero() && dr.Interval.To.Before(to) {
+			to = dr.Interval.To
+		}
+		d := to.Sub(r.Time)
+		if d <= 0 {
+			continue
+		}
+
+		a := Assess(r, th)
+		dc.Measured += d
+		if a.Comfortable() {
+			dc.Comfortable += d
+		}
+		if a.TooCold {
+			dc.TooCold += d
+		}
+		if a.TooWarm {
+			dc.TooWarm += d
+		}
+		if a.TooDry {
+			dc.TooDry += d
+		}
+		if a.TooHumid {
+			dc.TooHumid += d
+		}
+		if math.IsNaN(dc.MaxDewPoint) || a.DewPoint > dc.MaxDewPoint {
+			dc.MaxDewPoint = a.DewPoint
+		}
+
+		// extend the current risk period while the risk continues without a gap
+		if risk != nil && (!a.MouldRisk || r.Time.After(risk.To)) {
+			dc.addRiskPeriod(risk, th)
+			risk = nil
+		}
+		if !a.MouldRisk {
+			continue
+		}
+		if risk == nil {
+			risk = &RiskPeriod{From: r.Time, MaxSurfaceHumidity: a.SurfaceHumidity, MinSurfaceTemperature: a.SurfaceTemperature}
+		}
+		risk.To = to
+		risk.MaxSurfaceHumidity = math.Max(risk.MaxSurfaceHumidity, a.SurfaceHumidity)
+		risk.MinSurfaceTemperature = math.Min(risk.MinSurfaceTemperature, a.SurfaceTemperature)
+	}
+	if risk != nil {
+		dc.addRiskPeriod(risk, th)
+	}
+
+	if dc.Measured > 0 {
+		dc.Score = 100 * float64(dc.Comfortable) / float64(dc.Measured)
+	}
+	return dc
+}
+
+func (dc *DayComfort) addRiskPeriod(rp *RiskPeriod, th Thresholds) {
+	if rp.Duration() >= th.MouldMinDuration {
+		dc.MouldRisk = append(dc.MouldRisk, *rp)
+	}
+}
+
+// AnalyzeDays computes the comfort and mould risk of every day report.
+func AnalyzeDays(reports []tado.DayReport, th Thresholds) []DayComfort {
+	days := make([]DayComfort, len(reports))
+	for i := range reports {
+		days[i] = Analyze(&reports[i], th)
+	}
+	return days
+}
@@ -0,0 +1,89 @@
+package tadocomfort
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssess(t *testing.T) {
+	th := DefaultThresholds()
+	outside := -5.0
+
+	a := Assess(Reading{Temperature: 17, Humidity: 70, Outside: &outside}, th)
+	assert.True(t, a.TooCold)
+	assert.True(t, a.TooHumid)
+	assert.False(t, a.TooDry)
+	assert.False(t, a.TooWarm)
+	assert.False(t, a.Comfortable())
+	assert.InDelta(t, 10.4, a.SurfaceTemperature, 1e-9)
+	assert.True(t, a.MouldRisk)
+
+	a = Assess(Reading{Temperature: 25, Humidity: 30}, th)
+	assert.True(t, a.TooWarm)
+	assert.True(t, a.TooDry)
+	assert.False(t, a.MouldRisk)
+}
+
+func TestAnalyze(t *testing.T) {
+	dr := testDayReport()
+	dc := Analyze(dr, DefaultThresholds())
+
+	assert.Equal(t, dr.Interval, dc.Interval)
+	// the last reading before the missing humidity holds until 12:15
+	assert.Equal(t, 23*time.Hour+15*time.Minute, dc.Measured)
+	assert.Equal(t, 14*time.Hour+45*time.Minute, dc.Comfortable)
+	assert.Equal(t, 8*time.Hour, dc.TooCold)
+	assert.Equal(t, 6*time.Hour+30*time.Minute, dc.TooHumid)
+	assert.Equal(t, time.Duration(0), dc.TooWarm)
+	assert.Equal(t, time.Duration(0), dc.TooDry)
+	assert.InDelta(t, 100*14.75/23.25, dc.Score, 1e-9)
+	assert.InDelta(t, DewPoint(21, 80), dc.MaxDewPoint, 1e-9)
+
+	// the shower is too short to be reported
+	if assert.True(t, dc.HasMouldRisk()) && assert.Len(t, dc.MouldRisk, 1) {
+		rp := dc.MouldRisk[0]
+		assert.Equal(t, dr.Interval.From, rp.From)
+		assert.Equal(t, 6*time.Hour, rp.Duration())
+		assert.Equal(t, 100.0, rp.MaxSurfaceHumidity)
+		assert.InDelta(t, 12.6, rp.MinSurfaceTemperature, 1e-9)
+	}
+
+	th := DefaultThresholds()
+	th.MouldMinDuration = 30 * time.Minute
+	th.MinTemperature = 17
+	dc = Analyze(dr, th)
+	assert.Len(t, dc.MouldRisk, 2)
+	assert.Equal(t, time.Duration(0), dc.TooCold)
+}
+
+func TestAnalyze_ZeroThresholds(t *testing.T) {
+	dr := testDayReport()
+
+	// the zero thresholds use the defaults
+	assert.Equal(t, Analyze(dr, DefaultThresholds()), Analyze(dr, Thresholds{}))
+
+	// so do values that cannot be used
+	dc := Analyze(dr, Thresholds{MinTemperature: 20, MaxTemperature: 22, MinHumidity: 30, MaxHumidity: 70})
+	assert.Equal(t, 23*time.Hour+15*time.Minute, dc.Measured)
+	assert.Equal(t, 8*time.Hour, dc.TooCold)
+	assert.Len(t, dc.MouldRisk, 2)
+
+	// ranges that are not set use the default range
+	th := DefaultThresholds()
+	th.MouldMinDuration = time.Hour
+	assert.Equal(t, Analyze(dr, th), Analyze(dr, Thresholds{MouldMinDuration: time.Hour}))
+}
+
+func TestAnalyzeDays(t *testing.T) {
+	days := AnalyzeDays([]tado.DayReport{*testDayReport(), {}}, DefaultThresholds())
+	if assert.Len(t, days, 2) {
+		assert.True(t, days[0].HasMouldRisk())
+		assert.Equal(t, 0.0, days[1].Score)
+		assert.True(t, math.IsNaN(days[1].MaxDewPoint))
+		assert.False(t, days[1].HasMouldRisk())
+	}
+}
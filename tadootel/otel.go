@@ -0,0 +1,185 @@
+// Package tadootel adds OpenTelemetry tracing and metrics to a tado.Client.
+// It is a separate module, so the core module does not depend on OpenTelemetry.
+package tadootel
+
+import (
+	"context"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "github.com/SebastiaanKlippert/go-tado/tadootel"
+
+// Attribute keys used on spans and metrics
+const (
+	AttrCall           = attribute.Key("tado.call")
+	AttrHomeID         = attribute.Key("tado.home_id")
+	AttrZoneID         = attribute.Key("tado.zone_id")
+	AttrDeviceSerialNo = attribute.Key("tado.device_serial_no")
+	AttrGrantType      = attribute.Key("tado.grant_type")
+	AttrReason         = attribute.Key("tado.reason")
+	AttrOutcome        = attribute.Key("tado.outcome")
+	AttrMethod         = attribute.Key("http.request.method")
+	AttrPath           = attribute.Key("url.path")
+	AttrStatusCode     = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the global provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global provider is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// instruments holds the tracer and metric instruments
+type instruments struct {
+	tracer         trace.Tracer
+	requests       metric.Int64Counter
+	duration       metric.Float64Histogram
+	errors         metric.Int64Counter
+	tokenRefreshes metric.Int64Counter
+}
+
+// Instrument adds the OpenTelemetry interceptor to c as outermost interceptor.
+func Instrument(c *tado.Client, opts ...Option) error {
+	ic, err := NewInterceptor(opts...)
+	if err != nil {
+		return err
+	}
+	c.Interceptors = append([]tado.Interceptor{ic}, c.Interceptors...)
+	return nil
+}
+
+// NewInterceptor returns an interceptor that creates a span per API call, named after the input type,
+// for example tado.GetZoneState. A token refresh needed for the call is added as child span.
+// The parent span is taken from the context of the request.
+//
+// The following metrics are recorded:
+//   - tado.client.requests: the number of API calls
+//   - tado.client.request.duration: the duration of API calls in seconds
+//   - tado.client.errors: the number of failed API calls, by HTTP status
+//   - tado.client.token_refreshes: the number of access token acquisitions, by grant type and outcome
+func NewInterceptor(opts ...Option) (tado.Interceptor, error) {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName)
+	ins := &instruments{
+		tracer: cfg.tracerProvider.Tracer(ScopeName),
+	}
+	var err error
+	ins.requests, err = meter.Int64Counter("tado.client.requests",
+		metric.WithDescription("Number of Tado API calls"), metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	ins.duration, err = meter.Float64Histogram("tado.client.request.duration",
+		metric.WithDescription("Duration of Tado API calls"), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	ins.errors, err = meter.Int64Counter("tado.client.errors",
+		metric.WithDescription("Number of failed Tado API calls"), metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	ins.tokenRefreshes, err = meter.Int64Counter("tado.client.token_refreshes",
+		metric.WithDescription("Number of Tado access token acquisitions"), metric.WithUnit("{refresh}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next tado.Doer) tado.Doer {
+		return tado.DoerFunc(func(c *tado.Call) error {
+			return ins.do(next, c)
+		})
+	}, nil
+}
+
+// do executes the call within a span and records the metrics
+func (ins *instruments) do(next tado.Doer, c *tado.Call) error {
+	name := c.Name()
+	attrs := []attribute.KeyValue{
+		AttrCall.String(name),
+		AttrMethod.String(c.Method),
+		AttrPath.String(c.Path),
+	}
+	if id := c.HomeID(); id != 0 {
+		attrs = append(attrs, AttrHomeID.Int(id))
+	}
+	if id := c.ZoneID(); id != 0 {
+		attrs = append(attrs, AttrZoneID.Int(id))
+	}
+	if sn := c.DeviceSerialNo(); sn != "" {
+		attrs = append(attrs, AttrDeviceSerialNo.String(sn))
+	}
+
+	ctx, span := ins.tracer.Start(c.Request.Context(), "tado."+name,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	start := time.Now()
+	err := next.Do(c)
+	elapsed := time.Since(start)
+
+	if c.TokenRefresh != nil {
+		ins.recordTokenRefresh(ctx, c.TokenRefresh)
+	}
+
+	status := c.StatusCode()
+	if status != 0 {
+		span.SetAttributes(AttrStatusCode.Int(status))
+	}
+	metricAttrs := metric.WithAttributes(AttrCall.String(name), AttrMethod.String(c.Method), AttrStatusCode.Int(status))
+	ins.requests.Add(ctx, 1, metricAttrs)
+	ins.duration.Record(ctx, elapsed.Seconds(), metricAttrs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		ins.errors.Add(ctx, 1, metricAttrs)
+	}
+	return err
+}
+
+// recordTokenRefresh adds the token refresh as child span of the call, and counts it
+func (ins *instruments) recordTokenRefresh(ctx context.Context, tr *tado.TokenRefresh) {
+	_, span := ins.tracer.Start(ctx, "tado.TokenRefresh", trace.WithTimestamp(tr.Start),
+		trace.WithAttributes(AttrGrantType.String(tr.GrantType), AttrReason.String(tr.Reason)))
+	outcome := "success"
+	if tr.Err != nil {
+		outcome = "error"
+		span.RecordError(tr.Err, trace.WithTimestamp(tr.End))
+		span.SetStatus(codes.Error, tr.Err.Error())
+	}
+	span.End(trace.WithTimestamp(tr.End))
+
+	ins.tokenRefreshes.Add(ctx, 1, metric.WithAttributes(AttrGrantType.String(tr.GrantType), AttrOutcome.String(outcome)))
+}
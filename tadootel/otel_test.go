@@ -0,0 +1,135 @@
+package tadootel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeAPI is the innermost interceptor of the test client, it responds without sending requests
+func fakeAPI(status int, body string, refresh *tado.TokenRefresh) tado.Interceptor {
+	return func(next tado.Doer) tado.Doer {
+		return tado.DoerFunc(func(c *tado.Call) error {
+			c.TokenRefresh = refresh
+			c.Response = &http.Response{StatusCode: status}
+			c.ResponseBody = []byte(body)
+			if status >= http.StatusBadRequest {
+				return &tado.HTTPError{StatusCode: status, Body: body}
+			}
+			return nil
+		})
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	start := time.Now()
+	refresh := &tado.TokenRefresh{
+		Start:     start,
+		End:       start.Add(50 * time.Millisecond),
+		GrantType: tado.GrantTypeRefreshToken,
+		Reason:    "expired",
+	}
+
+	c := tado.NewClient("user", "pass")
+	c.Interceptors = []tado.Interceptor{fakeAPI(http.StatusOK, `{"tadoMode": "HOME"}`, refresh)}
+	err := Instrument(c, WithTracerProvider(tp), WithMeterProvider(mp))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetZoneState(&tado.GetZoneStateInput{HomeID: 12345, ZoneID: 3})
+	assert.NoError(t, err)
+
+	c.Interceptors[1] = fakeAPI(http.StatusUnprocessableEntity, `invalid`, nil)
+	_, err = c.PutDazzleMode(&tado.PutDazzleModeInput{HomeID: 12345, ZoneID: 3, Enabled: true})
+	assert.Error(t, err)
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 3) {
+		// the token refresh ends first, as it is recorded when the call returns
+		assert.Equal(t, "tado.TokenRefresh", ended[0].Name())
+		assert.Equal(t, start.UnixNano(), ended[0].StartTime().UnixNano())
+		assert.Equal(t, refresh.End.UnixNano(), ended[0].EndTime().UnixNano())
+		assert.Contains(t, ended[0].Attributes(), AttrGrantType.String(tado.GrantTypeRefreshToken))
+
+		assert.Equal(t, "tado.GetZoneState", ended[1].Name())
+		assert.Equal(t, ended[1].SpanContext().SpanID(), ended[0].Parent().SpanID())
+		assert.Contains(t, ended[1].Attributes(), AttrHomeID.Int(12345))
+		assert.Contains(t, ended[1].Attributes(), AttrZoneID.Int(3))
+		assert.Contains(t, ended[1].Attributes(), AttrStatusCode.Int(http.StatusOK))
+		assert.Equal(t, codes.Unset, ended[1].Status().Code)
+
+		assert.Equal(t, "tado.PutDazzleMode", ended[2].Name())
+		assert.Equal(t, codes.Error, ended[2].Status().Code)
+		assert.Contains(t, ended[2].Attributes(), AttrStatusCode.Int(http.StatusUnprocessableEntity))
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	sums := map[string]int64{}
+	var histogramCount uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch d := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range d.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range d.DataPoints {
+					histogramCount += dp.Count
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"tado.client.requests":        2,
+		"tado.client.errors":          1,
+		"tado.client.token_refreshes": 1,
+	}, sums)
+	assert.Equal(t, uint64(2), histogramCount)
+}
+
+func TestTokenRefreshError(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	ic, err := NewInterceptor(WithTracerProvider(tp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := tado.NewClient("user", "pass")
+	c.Interceptors = []tado.Interceptor{ic, func(next tado.Doer) tado.Doer {
+		return tado.DoerFunc(func(c *tado.Call) error {
+			c.TokenRefresh = &tado.TokenRefresh{GrantType: tado.GrantTypePassword, Err: errors.New("bad credentials")}
+			return c.TokenRefresh.Err
+		})
+	}}
+	_, err = c.GetMe()
+	assert.Error(t, err)
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 2) {
+		assert.Equal(t, codes.Error, ended[0].Status().Code)
+		assert.Equal(t, "tado.GetMe", ended[1].Name())
+		assert.NotContains(t, ended[1].Attributes(), attribute.Key("tado.home_id"))
+	}
+}
@@ -0,0 +1,150 @@
+package tado
+
+import (
+	"math"
+	"strconv"
+)
+
+// TemperatureUnit is an enum type for the temperature unit used by a home
+type TemperatureUnit string
+
+const (
+	// TemperatureUnitCelsius is used for homes displaying temperatures in degrees Celsius
+	TemperatureUnitCelsius TemperatureUnit = "CELSIUS"
+
+	// TemperatureUnitFahrenheit is used for homes displaying temperatures in degrees Fahrenheit
+	TemperatureUnitFahrenheit TemperatureUnit = "FAHRENHEIT"
+)
+
+// temperatureTolerance is the difference below which two temperatures are considered equal
+const temperatureTolerance = 0.005
+
+// Temperature is a temperature as used by the Tado API, it is expressed in both Celsius and Fahrenheit.
+// When only one of the units is set, the value in the other unit is calculated when it is requested.
+type Temperature struct {
+	Celsius    float64 `json:"celsius,omitempty"`
+	Fahrenheit float64 `json:"fahrenheit,omitempty"`
+}
+
+// NewTemperature returns a Temperature for value in unit, the value in the other unit is calculated.
+func NewTemperature(value float64, unit TemperatureUnit) Temperature {
+	if unit == TemperatureUnitFahrenheit {
+		return Temperature{
+			Celsius:    FahrenheitToCelsius(value),
+			Fahrenheit: value,
+		}
+	}
+	return Temperature{
+		Celsius:    value,
+		Fahrenheit: CelsiusToFahrenheit(value),
+	}
+}
+
+// CelsiusToFahrenheit converts degrees Celsius to degrees Fahrenheit.
+func CelsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+// FahrenheitToCelsius converts degrees Fahrenheit to degrees Celsius.
+func FahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+// Value returns the temperature in unit, an empty unit is treated as Celsius.
+func (t Temperature) Value(unit TemperatureUnit) float64 {
+	if unit == TemperatureUnitFahrenheit {
+		if t.Fahrenheit == 0 && t.Celsius != 0 {
+			return CelsiusToFahrenheit(t.Celsius)
+		}
+		return t.Fahrenheit
+	}
+	if t.Celsius == 0 && t.Fahrenheit != 0 {
+		return FahrenheitToCelsius(t.Fahrenheit)
+	}
+	return t.Celsius
+}
+
+// NewTemperatureOffset returns a temperature difference of value in unit, for example a device temperature offset.
+// Unlike NewTemperature the value in the other unit is only scaled, a difference of 0°F is 0°C.
+func NewTemperatureOffset(value float64, unit TemperatureUnit) Temperature {
+	if unit == TemperatureUnitFahrenheit {
+		return Temperature{
+			Celsius:    value * 5 / 9,
+			Fahrenheit: value,
+		}
+	}
+	return Temperature{
+		Celsius:    value,
+		Fahrenheit: value * 9 / 5,
+	}
+}
+
+// OffsetValue returns the temperature difference in unit, an empty unit is treated as Celsius.
+// Use it instead of Value for temperature offsets.
+func (t Temperature) OffsetValue(unit TemperatureUnit) float64 {
+	if unit == TemperatureUnitFahrenheit {
+		if t.Fahrenheit == 0 && t.Celsius != 0 {
+			return t.Celsius * 9 / 5
+		}
+		return t.Fahrenheit
+	}
+	if t.Celsius == 0 && t.Fahrenheit != 0 {
+		return t.Fahrenheit * 5 / 9
+	}
+	return t.Celsius
+}
+
+// OffsetEqual returns true if t and o are the same temperature difference.
+func (t Temperature) OffsetEqual(o Temperature) bool {
+	return math.Abs(t.OffsetValue(TemperatureUnitCelsius)-o.OffsetValue(TemperatureUnitCelsius)) <= temperatureTolerance
+}
+
+// FormatOffset returns the temperature difference in unit with the unit symbol, for example -0.5°C.
+func (t Temperature) FormatOffset(unit TemperatureUnit) string {
+	return formatTemperature(t.OffsetValue(unit), unit)
+}
+
+// Round returns the temperature rounded to the nearest step in unit, for example 0.1 for most Celsius zones.
+// The value in the other unit is calculated from the rounded value.
+func (t Temperature) Round(step float64, unit TemperatureUnit) Temperature {
+	v := t.Value(unit)
+	if step > 0 {
+		v = math.Round(v/step) * step
+		// remove floating point noise, no Tado step is smaller than 0.01
+		v = math.Round(v*100) / 100
+	}
+	return NewTemperature(v, unit)
+}
+
+// Compare returns -1 if t is lower than o, 1 if t is higher than o and 0 if both temperatures are equal.
+func (t Temperature) Compare(o Temperature) int {
+	d := t.Value(TemperatureUnitCelsius) - o.Value(TemperatureUnitCelsius)
+	switch {
+	case d < -temperatureTolerance:
+		return -1
+	case d > temperatureTolerance:
+		return 1
+	}
+	return 0
+}
+
+// Equal returns true if t and o are the same temperature.
+func (t Temperature) Equal(o Temperature) bool {
+	return t.Compare(o) == 0
+}
+
+// Format returns the temperature in unit with the unit symbol, for example 21.5°C.
+// Use Home.TemperatureUnit to format temperatures the way the home is configured.
+func (t Temperature) Format(unit TemperatureUnit) string {
+	return formatTemperature(t.Value(unit), unit)
+}
+
+// formatTemperature returns v rounded to two decimals with the symbol of unit
+func formatTemperature(v float64, unit TemperatureUnit) string {
+	v = math.Round(v*100) / 100
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if unit == TemperatureUnitFahrenheit {
+		return s + "°F"
+	}
+	return s + "°C"
+}
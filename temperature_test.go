@@ -0,0 +1,94 @@
+package tado
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTemperature(t *testing.T) {
+	c := NewTemperature(20, TemperatureUnitCelsius)
+	assert.Equal(t, Temperature{Celsius: 20, Fahrenheit: 68}, c)
+
+	f := NewTemperature(212, TemperatureUnitFahrenheit)
+	assert.Equal(t, Temperature{Celsius: 100, Fahrenheit: 212}, f)
+}
+
+func TestTemperature_Value(t *testing.T) {
+	tt := Temperature{Celsius: 21.5, Fahrenheit: 70.7}
+	assert.Equal(t, 21.5, tt.Value(TemperatureUnitCelsius))
+	assert.Equal(t, 70.7, tt.Value(TemperatureUnitFahrenheit))
+	assert.Equal(t, 21.5, tt.Value(""))
+
+	// only one unit set
+	assert.InDelta(t, 70.7, Temperature{Celsius: 21.5}.Value(TemperatureUnitFahrenheit), 0.001)
+	assert.InDelta(t, 21.5, Temperature{Fahrenheit: 70.7}.Value(TemperatureUnitCelsius), 0.001)
+}
+
+func TestTemperature_Round(t *testing.T) {
+	tt := Temperature{Celsius: 21.26, Fahrenheit: 70.27}
+
+	r := tt.Round(0.1, TemperatureUnitCelsius)
+	assert.Equal(t, 21.3, r.Celsius)
+	assert.InDelta(t, 70.34, r.Fahrenheit, 0.001)
+
+	r = tt.Round(0.5, TemperatureUnitCelsius)
+	assert.Equal(t, 21.5, r.Celsius)
+
+	r = tt.Round(1, TemperatureUnitFahrenheit)
+	assert.Equal(t, 70.0, r.Fahrenheit)
+
+	r = tt.Round(0, TemperatureUnitCelsius)
+	assert.Equal(t, 21.26, r.Celsius)
+}
+
+func TestTemperature_Compare(t *testing.T) {
+	a := Temperature{Celsius: 20, Fahrenheit: 68}
+	b := Temperature{Celsius: 21}
+
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(Temperature{Fahrenheit: 68}))
+	assert.True(t, a.Equal(Temperature{Celsius: 20.001}))
+	assert.False(t, a.Equal(b))
+}
+
+func TestTemperature_Format(t *testing.T) {
+	tt := Temperature{Celsius: 21.5, Fahrenheit: 70.7}
+	assert.Equal(t, "21.5°C", tt.Format(TemperatureUnitCelsius))
+	assert.Equal(t, "70.7°F", tt.Format(TemperatureUnitFahrenheit))
+
+	h := &Home{TemperatureUnit: TemperatureUnitFahrenheit}
+	assert.Equal(t, "70.7°F", h.FormatTemperature(tt))
+}
+
+func TestTemperatureOffset(t *testing.T) {
+	o := NewTemperatureOffset(1.8, TemperatureUnitFahrenheit)
+	assert.InDelta(t, 1.0, o.Celsius, 1e-9)
+	assert.Equal(t, 1.8, o.Fahrenheit)
+	assert.InDelta(t, 1.0, Temperature{Fahrenheit: 1.8}.OffsetValue(TemperatureUnitCelsius), 1e-9)
+	assert.InDelta(t, -0.9, Temperature{Celsius: -0.5}.OffsetValue(TemperatureUnitFahrenheit), 1e-9)
+
+	// a difference of 0°F is 0°C, not -17.78°C
+	zero := NewTemperatureOffset(0, TemperatureUnitFahrenheit)
+	assert.Equal(t, Temperature{}, zero)
+	assert.True(t, zero.OffsetEqual(Temperature{Celsius: 0, Fahrenheit: 0}))
+	assert.True(t, o.OffsetEqual(Temperature{Celsius: 1}))
+	assert.False(t, o.OffsetEqual(zero))
+
+	assert.Equal(t, "1°C", o.FormatOffset(TemperatureUnitCelsius))
+	assert.Equal(t, "1.8°F", o.FormatOffset(TemperatureUnitFahrenheit))
+	assert.Equal(t, "-0.9°F", Temperature{Celsius: -0.5}.FormatOffset(TemperatureUnitFahrenheit))
+}
+
+func TestTemperature_JSON(t *testing.T) {
+	tt := new(Temperature)
+	err := json.Unmarshal([]byte(`{"celsius": 18.5, "fahrenheit": 65.3}`), tt)
+	assert.NoError(t, err)
+	assert.Equal(t, Temperature{Celsius: 18.5, Fahrenheit: 65.3}, *tt)
+
+	b, err := json.Marshal(Temperature{Celsius: 17})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"celsius":17}`, string(b))
+}
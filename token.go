@@ -0,0 +1,174 @@
+package tado
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+)
+
+// tokenExpiryMargin is the minimum time an access token must still be valid to be used
+const tokenExpiryMargin = 5 * time.Second
+
+// backgroundRefreshRetry is the time to wait after a failed background refresh, and the
+// minimum time between two background refreshes
+var backgroundRefreshRetry = 10 * time.Second
+
+// token is an immutable snapshot of the access token, it is replaced as a whole when the token is refreshed
+type token struct {
+	tr         *tadoauth.TokenResponse
+	validUntil time.Time
+}
+
+// validFor returns true if the token is valid for at least d
+func (t *token) validFor(d time.Duration) bool {
+	return t != nil && t.validUntil.After(time.Now().Add(d))
+}
+
+// setToken replaces the token
+func (c *Client) setToken(tr *tadoauth.TokenResponse, validUntil time.Time) {
+	c.token.Store(&token{tr: tr, validUntil: validUntil})
+}
+
+// validateAccessToken returns an access token that is valid for at least 5 more seconds, and the token refresh
+// if one was needed. Valid tokens are returned without locking, so requests are not blocked by a refresh.
+func (c *Client) validateAccessToken() (string, *TokenRefresh, error) {
+	if t := c.token.Load(); t.validFor(tokenExpiryMargin) {
+		return t.tr.AccessToken, nil, nil
+	}
+	t, refresh, err := c.refreshAccessToken(tokenExpiryMargin)
+	if err != nil {
+		return "", refresh, err
+	}
+	return t.tr.AccessToken, refresh, nil
+}
+
+// refreshAccessToken gets a new access token unless the current token is valid for at least minValid.
+// Only one goroutine refreshes at a time, goroutines waiting for the refresh use the new token.
+func (c *Client) refreshAccessToken(minValid time.Duration) (*token, *TokenRefresh, error) {
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	// the token may have been refreshed while waiting for the lock
+	current := c.token.Load()
+	if current.validFor(minValid) {
+		return current, nil, nil
+	}
+
+	// another client of the same account may have stored a newer token
+	if stored := c.loadStoredToken(); stored != nil && (current == nil || stored.validUntil.After(current.validUntil)) {
+		current = stored
+		c.token.Store(current)
+		if current.validFor(minValid) {
+			return current, nil, nil
+		}
+	}
+
+	reason := "expires soon"
+	switch {
+	case current == nil || current.tr == nil:
+		reason = "no access token"
+	case current.validUntil.Before(time.Now()):
+		reason = "expired"
+	}
+
+	var tr *tadoauth.TokenResponse
+	var err error
+	refresh := &TokenRefresh{Start: time.Now(), Reason: reason}
+	if current != nil && current.tr != nil && current.tr.RefreshToken != "" {
+		// exchange refresh token for new access token
+		refresh.GrantType = GrantTypeRefreshToken
+		c.log(slog.LevelInfo, "tado refreshing access token", slog.String("reason", reason),
+			slog.Time("validUntil", current.validUntil))
+		tr, err = c.authClient.RefreshToken(current.tr.RefreshToken)
+	} else {
+		// get new token based on username and password
+		refresh.GrantType = GrantTypePassword
+		c.log(slog.LevelInfo, "tado requesting access token", slog.String("reason", reason),
+			slog.String("username", c.username))
+		tr, err = c.authClient.GetToken(c.username, c.password)
+	}
+	refresh.End = time.Now()
+	if err != nil {
+		refresh.Err = err
+		c.log(slog.LevelError, "tado access token failed", slog.String("error", err.Error()))
+		// keep a token that can still be used, otherwise drop it so the next attempt uses the password
+		if !current.validFor(tokenExpiryMargin) {
+			c.token.Store(nil)
+		}
+		return nil, refresh, err
+	}
+
+	t := &token{tr: tr, validUntil: time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)}
+	c.token.Store(t)
+	c.saveStoredToken(t)
+	c.log(slog.LevelInfo, "tado access token acquired", slog.Time("validUntil", t.validUntil))
+	return t, refresh, nil
+}
+
+// StartBackgroundRefresh starts a goroutine that refreshes the access token before it expires,
+// until ctx is done or StopBackgroundRefresh is called.
+// The token is refreshed when it is valid for less than before, so requests do not have to wait for a refresh.
+// A failed refresh is retried after 10 seconds, and the token is never refreshed more than once per 10 seconds.
+func (c *Client) StartBackgroundRefresh(ctx context.Context, before time.Duration) {
+	if before < tokenExpiryMargin {
+		before = tokenExpiryMargin
+	}
+	retry := backgroundRefreshRetry
+	ctx, cancel := context.WithCancel(ctx)
+	c.backgroundMutex.Lock()
+	c.backgroundStops = append(c.backgroundStops, cancel)
+	c.backgroundMutex.Unlock()
+	go func() {
+		for {
+			// wait until the token expires within before, but at least half of its remaining lifetime,
+			// so tokens with a lifetime shorter than before are not refreshed continuously, and never
+			// less than retry so tokens that are already expired when received do not cause a busy loop
+			wait := time.Duration(0)
+			if t := c.token.Load(); t != nil {
+				wait = time.Until(t.validUntil.Add(-before))
+				if half := time.Until(t.validUntil) / 2; half > wait {
+					wait = half
+				}
+				if wait < retry {
+					wait = retry
+				}
+			}
+			if wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			_, _, err := c.refreshAccessToken(before)
+			if err == nil {
+				continue
+			}
+			timer := time.NewTimer(retry)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// StopBackgroundRefresh stops every background refresh started with StartBackgroundRefresh.
+func (c *Client) StopBackgroundRefresh() {
+	c.backgroundMutex.Lock()
+	defer c.backgroundMutex.Unlock()
+	for _, stop := range c.backgroundStops {
+		stop()
+	}
+	c.backgroundStops = nil
+}
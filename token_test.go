@@ -0,0 +1,210 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingAuthClient is a concurrency safe auth client that returns numbered tokens
+type countingAuthClient struct {
+	calls     int32
+	expiresIn int
+	delay     time.Duration
+	block     chan struct{}
+	fail      int32 // number of calls that fail
+}
+
+func (ca *countingAuthClient) token() (*tadoauth.TokenResponse, error) {
+	n := atomic.AddInt32(&ca.calls, 1)
+	if ca.block != nil {
+		<-ca.block
+	}
+	time.Sleep(ca.delay)
+	if atomic.AddInt32(&ca.fail, -1) >= 0 {
+		return nil, errors.New("auth failed")
+	}
+	return &tadoauth.TokenResponse{
+		AccessToken:  "token" + strconv.Itoa(int(n)),
+		RefreshToken: "refresh" + strconv.Itoa(int(n)),
+		ExpiresIn:    ca.expiresIn,
+	}, nil
+}
+
+func (ca *countingAuthClient) GetToken(username, password string) (*tadoauth.TokenResponse, error) {
+	return ca.token()
+}
+
+func (ca *countingAuthClient) RefreshToken(refreshToken string) (*tadoauth.TokenResponse, error) {
+	return ca.token()
+}
+
+func (ca *countingAuthClient) Calls() int {
+	return int(atomic.LoadInt32(&ca.calls))
+}
+
+func TestClient_ConcurrentRefresh(t *testing.T) {
+	var mutex sync.Mutex
+	tokens := map[string]int{}
+	client, server := setupTestClientAndServer(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		tokens[r.Header.Get("Authorization")]++
+		mutex.Unlock()
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	defer server.Close()
+
+	auth := &countingAuthClient{expiresIn: 600, delay: 20 * time.Millisecond}
+	client.authClient = auth
+	client.token.Store(nil)
+
+	const goroutines = 300
+	wg := new(sync.WaitGroup)
+	errs := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetMe()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, auth.Calls(), "token must be acquired once")
+	assert.Equal(t, map[string]int{"Bearer token1": goroutines}, tokens)
+}
+
+func TestClient_RefreshDoesNotBlockReaders(t *testing.T) {
+	client, server := setupTestClientAndServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	defer server.Close()
+
+	auth := &countingAuthClient{expiresIn: 600, block: make(chan struct{})}
+	client.authClient = auth
+	client.setToken(&tadoauth.TokenResponse{AccessToken: "valid", RefreshToken: "r"}, time.Now().Add(time.Minute))
+
+	// start a refresh that blocks until released
+	refreshed := make(chan error)
+	go func() {
+		_, _, err := client.refreshAccessToken(2 * time.Minute)
+		refreshed <- err
+	}()
+	for auth.Calls() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// requests with the still valid token are not blocked by the refresh
+	const goroutines = 200
+	done := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			_, err := client.GetMe()
+			done <- err
+		}()
+	}
+	timeout := time.After(5 * time.Second)
+	for i := 0; i < goroutines; i++ {
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-timeout:
+			t.Fatal("requests are blocked by the refresh")
+		}
+	}
+
+	close(auth.block)
+	assert.NoError(t, <-refreshed)
+	token, _, err := client.validateAccessToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "token1", token)
+}
+
+func TestClient_RefreshFailure(t *testing.T) {
+	client := NewClient("user", "pass")
+	auth := &countingAuthClient{expiresIn: 600, fail: 1}
+	client.authClient = auth
+
+	// a failed refresh keeps a token that is still valid
+	client.setToken(&tadoauth.TokenResponse{AccessToken: "valid", RefreshToken: "r"}, time.Now().Add(time.Minute))
+	_, refresh, err := client.refreshAccessToken(2 * time.Minute)
+	if assert.Error(t, err) && assert.NotNil(t, refresh) {
+		assert.Equal(t, err, refresh.Err)
+	}
+	token, _, err := client.validateAccessToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "valid", token)
+
+	// an expired token is dropped after a failed refresh, so the password is used next
+	atomic.StoreInt32(&auth.fail, 1)
+	client.setToken(&tadoauth.TokenResponse{AccessToken: "expired", RefreshToken: "r"}, time.Now())
+	_, _, err = client.validateAccessToken()
+	assert.Error(t, err)
+	assert.Nil(t, client.token.Load())
+	_, refresh, err = client.validateAccessToken()
+	if assert.NoError(t, err) {
+		assert.Equal(t, GrantTypePassword, refresh.GrantType)
+		assert.Equal(t, "no access token", refresh.Reason)
+	}
+}
+
+func TestClient_StartBackgroundRefresh(t *testing.T) {
+	defer func(d time.Duration) { backgroundRefreshRetry = d }(backgroundRefreshRetry)
+	backgroundRefreshRetry = 10 * time.Millisecond
+
+	client := NewClient("user", "pass")
+	// the first call fails and is retried, the tokens expire within the refresh window so they are
+	// refreshed after half their lifetime
+	auth := &countingAuthClient{expiresIn: 1, fail: 1}
+	client.authClient = auth
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client.StartBackgroundRefresh(ctx, time.Minute)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for auth.Calls() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("token refreshed %d times", auth.Calls())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	calls := auth.Calls()
+	time.Sleep(time.Second)
+	assert.Equal(t, calls, auth.Calls(), "refresh must stop when the context is done")
+}
+
+func TestClient_StartBackgroundRefresh_ExpiredToken(t *testing.T) {
+	defer func(d time.Duration) { backgroundRefreshRetry = d }(backgroundRefreshRetry)
+	backgroundRefreshRetry = 100 * time.Millisecond
+
+	client := NewClient("user", "pass")
+	// tokens with expires_in 0 are expired when they are received, they must not be refreshed in a loop
+	auth := &countingAuthClient{expiresIn: 0}
+	client.authClient = auth
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client.StartBackgroundRefresh(ctx, time.Minute)
+	time.Sleep(550 * time.Millisecond)
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	calls := auth.Calls()
+	assert.GreaterOrEqual(t, calls, 2)
+	assert.LessOrEqual(t, calls, 7)
+}
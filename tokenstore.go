@@ -0,0 +1,90 @@
+package tado
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SebastiaanKlippert/go-tado/tadoauth"
+)
+
+// StoredToken is an access token saved in a TokenStore.
+type StoredToken struct {
+	tadoauth.TokenResponse
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+// TokenStore saves access and refresh tokens per account, so clients of the same account can share them.
+// It must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the token of account, or nil if there is none.
+	Load(account string) (*StoredToken, error)
+	Save(account string, t *StoredToken) error
+}
+
+// MemoryTokenStore is an in-memory TokenStore.
+type MemoryTokenStore struct {
+	mutex  sync.Mutex
+	tokens map[string]StoredToken
+}
+
+// NewMemoryTokenStore returns an empty in-memory token store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]StoredToken)}
+}
+
+// Load returns the token of account
+func (ms *MemoryTokenStore) Load(account string) (*StoredToken, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	t, ok := ms.tokens[account]
+	if !ok {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+// Save saves the token of account
+func (ms *MemoryTokenStore) Save(account string, t *StoredToken) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	ms.tokens[account] = *t
+	return nil
+}
+
+// SetTokenStore sets the store the client loads and saves its tokens for account.
+// A stored token is used instead of authenticating when the client has no valid token.
+func (c *Client) SetTokenStore(store TokenStore, account string) {
+	c.tokenStore = store
+	c.tokenAccount = account
+}
+
+// loadStoredToken returns the stored token, or nil if there is no store or no token
+func (c *Client) loadStoredToken() *token {
+	if c.tokenStore == nil {
+		return nil
+	}
+	st, err := c.tokenStore.Load(c.tokenAccount)
+	if err != nil {
+		c.log(slog.LevelError, "tado loading stored token failed", slog.String("account", c.tokenAccount),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	if st == nil {
+		return nil
+	}
+	tr := st.TokenResponse
+	return &token{tr: &tr, validUntil: st.ValidUntil}
+}
+
+// saveStoredToken saves t in the store, if there is one
+func (c *Client) saveStoredToken(t *token) {
+	if c.tokenStore == nil {
+		return
+	}
+	err := c.tokenStore.Save(c.tokenAccount, &StoredToken{TokenResponse: *t.tr, ValidUntil: t.validUntil})
+	if err != nil {
+		c.log(slog.LevelError, "tado saving token failed", slog.String("account", c.tokenAccount),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,244 @@
+package tado
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// DefaultWatchInterval is the time between polls of a Watcher when no interval is set
+const DefaultWatchInterval = time.Minute
+
+// minWatchInterval is the shortest time between polls of a Watcher
+var minWatchInterval = 10 * time.Second
+
+// EventType is an enum type for the changes detected by a Watcher
+type EventType string
+
+const (
+	// EventTemperatureChanged is sent when the measured inside temperature of a zone changed
+	EventTemperatureChanged EventType = "TEMPERATURE_CHANGED"
+
+	// EventSetpointChanged is sent when the power or temperature setting of a zone changed
+	EventSetpointChanged EventType = "SETPOINT_CHANGED"
+
+	// EventOverlayStarted is sent when an overlay was set on a zone, also when it replaced another overlay
+	EventOverlayStarted EventType = "OVERLAY_STARTED"
+
+	// EventOverlayEnded is sent when the overlay of a zone was removed or expired
+	EventOverlayEnded EventType = "OVERLAY_ENDED"
+
+	// EventHeatingPowerChanged is sent when the heating power percentage of a zone changed
+	EventHeatingPowerChanged EventType = "HEATING_POWER_CHANGED"
+
+	// EventLinkOffline is sent when a zone lost its connection
+	EventLinkOffline EventType = "LINK_OFFLINE"
+
+	// EventLinkOnline is sent when a zone is connected again after it was offline
+	EventLinkOnline EventType = "LINK_ONLINE"
+
+	// EventOpenWindowDetected is sent when an open window was detected in a zone
+	EventOpenWindowDetected EventType = "OPEN_WINDOW_DETECTED"
+
+	// EventError is sent when polling the zone states failed, the Watcher keeps polling
+	EventError EventType = "ERROR"
+)
+
+// Event is a change in the state of a zone detected by a Watcher.
+// Previous and Current contain the zone states before and after the change, for EventError only Err is set.
+type Event struct {
+	Type     EventType
+	HomeID   int
+	ZoneID   int
+	Time     time.Time
+	Previous ZoneState
+	Current  ZoneState
+	Err      error
+}
+
+// Watcher polls the zones of a home and sends an Event for every change, use NewWatcher to create one.
+// Every poll uses a single GetZoneStates request for all zones.
+type Watcher struct {
+	// Interval is the time between polls, it defaults to DefaultWatchInterval.
+	Interval time.Duration
+
+	// Jitter is the fraction by which every interval is randomly extended, 0.1 extends intervals by up to 10%.
+	// This prevents many watchers that were started together from polling at the same moment.
+	Jitter float64
+
+	// RequestsPerDay is the number of API requests the Watcher may use per day, 0 means there is no limit.
+	// The interval is extended when polling at Interval would use more requests.
+	RequestsPerDay int
+
+	client *Client
+	homeID int
+}
+
+// NewWatcher returns a new Watcher for all zones of a home.
+func NewWatcher(c *Client, homeID int) *Watcher {
+	return &Watcher{
+		Interval: DefaultWatchInterval,
+		Jitter:   0.1,
+		client:   c,
+		homeID:   homeID,
+	}
+}
+
+// Watch starts polling and returns the channel on which the events are sent.
+// The first poll is used as a baseline and does not send events.
+// Watch can be called more than once, every call polls independently.
+// Polling stops and the channel is closed when ctx is done.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+	go w.run(ctx, events)
+	return events
+}
+
+func (w *Watcher) run(ctx context.Context, events chan<- Event) {
+	defer close(events)
+
+	var previous map[int]ZoneState
+	for {
+		current, err := w.poll()
+		if err != nil {
+			e := Event{
+				Type:   EventError,
+				HomeID: w.homeID,
+				Time:   time.Now(),
+				Err:    err,
+			}
+			if !w.send(ctx, events, e) {
+				return
+			}
+		} else {
+			if previous != nil {
+				for _, e := range w.diff(previous, current) {
+					if !w.send(ctx, events, e) {
+						return
+					}
+				}
+			}
+			previous = current
+		}
+
+		timer := time.NewTimer(w.nextInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (w *Watcher) send(ctx context.Context, events chan<- Event, e Event) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- e:
+		return true
+	}
+}
+
+// poll returns the state of every zone
+func (w *Watcher) poll() (map[int]ZoneState, error) {
+	return w.client.GetZoneStates(&GetZoneStatesInput{HomeID: w.homeID})
+}
+
+// nextInterval returns the time until the next poll, taking the request budget into account
+func (w *Watcher) nextInterval() time.Duration {
+	interval := w.Interval
+	if interval == 0 {
+		interval = DefaultWatchInterval
+	}
+	if interval < minWatchInterval {
+		interval = minWatchInterval
+	}
+	if w.RequestsPerDay > 0 {
+		// every poll uses a single request
+		budget := 24 * time.Hour / time.Duration(w.RequestsPerDay)
+		if interval < budget {
+			interval = budget
+		}
+	}
+	if w.Jitter > 0 {
+		interval += time.Duration(rand.Float64() * w.Jitter * float64(interval))
+	}
+	return interval
+}
+
+// diff returns the events for all changes between the previous and current zone states
+func (w *Watcher) diff(previous, current map[int]ZoneState) []Event {
+	zoneIDs := make([]int, 0, len(current))
+	for id := range current {
+		zoneIDs = append(zoneIDs, id)
+	}
+	sort.Ints(zoneIDs)
+
+	now := time.Now()
+	var events []Event
+	for _, id := range zoneIDs {
+		p, ok := previous[id]
+		if !ok {
+			continue
+		}
+		c, ok := current[id]
+		if !ok {
+			continue
+		}
+		for _, et := range zoneStateChanges(p, c) {
+			events = append(events, Event{
+				Type:     et,
+				HomeID:   w.homeID,
+				ZoneID:   id,
+				Time:     now,
+				Previous: p,
+				Current:  c,
+			})
+		}
+	}
+	return events
+}
+
+// zoneStateChanges returns the types of all changes between two states of the same zone
+func zoneStateChanges(p, c ZoneState) []EventType {
+	var changes []EventType
+	if !p.SensorDataPoints.InsideTemperature.Equal(c.SensorDataPoints.InsideTemperature.Temperature) {
+		changes = append(changes, EventTemperatureChanged)
+	}
+	if p.Setting.Power != c.Setting.Power || !p.Setting.Temperature.Equal(c.Setting.Temperature) {
+		changes = append(changes, EventSetpointChanged)
+	}
+	if c.OverlayType != "" && (p.OverlayType == "" || overlayReplaced(p.Overlay, c.Overlay)) {
+		changes = append(changes, EventOverlayStarted)
+	}
+	if p.OverlayType != "" && c.OverlayType == "" {
+		changes = append(changes, EventOverlayEnded)
+	}
+	if p.ActivityDataPoints.HeatingPower.Percentage != c.ActivityDataPoints.HeatingPower.Percentage {
+		changes = append(changes, EventHeatingPowerChanged)
+	}
+	if p.Link.State != LinkStateOffline && c.Link.State == LinkStateOffline {
+		changes = append(changes, EventLinkOffline)
+	}
+	if p.Link.State == LinkStateOffline && c.Link.State != LinkStateOffline {
+		changes = append(changes, EventLinkOnline)
+	}
+	if !openWindowDetected(p) && openWindowDetected(c) {
+		changes = append(changes, EventOpenWindowDetected)
+	}
+	return changes
+}
+
+// overlayReplaced returns true if c is another overlay than p, the remaining time of a timer is ignored
+func overlayReplaced(p, c Overlay) bool {
+	return p.Type != c.Type || p.Setting != c.Setting ||
+		p.Termination.Type != c.Termination.Type ||
+		p.Termination.DurationInSeconds != c.Termination.DurationInSeconds ||
+		!p.Termination.Expiry.Equal(c.Termination.Expiry)
+}
+
+func openWindowDetected(zs ZoneState) bool {
+	return zs.OpenWindowDetected || zs.OpenWindow != nil
+}
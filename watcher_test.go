@@ -0,0 +1,179 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher_Watch(t *testing.T) {
+	defer func(d time.Duration) { minWatchInterval = d }(minWatchInterval)
+	minWatchInterval = time.Millisecond
+
+	states := []string{
+		`{"zoneStates": {
+			"1": {"setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 19}}, "sensorDataPoints": {"insideTemperature": {"celsius": 18.5}}},
+			"2": {"link": {"state": "ONLINE"}, "sensorDataPoints": {"insideTemperature": {"celsius": 20}}}
+		}}`,
+		`{"zoneStates": {
+			"1": {"setting": {"type": "HEATING", "power": "ON", "temperature": {"celsius": 21}}, "overlayType": "MANUAL", "sensorDataPoints": {"insideTemperature": {"celsius": 18.6}}, "activityDataPoints": {"heatingPower": {"percentage": 40}}},
+			"2": {"link": {"state": "OFFLINE"}, "openWindow": {"durationInSeconds": 900}, "sensorDataPoints": {"insideTemperature": {"celsius": 20}}}
+		}}`,
+	}
+
+	var mu sync.Mutex
+	calls := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "/v2/homes/12345/zoneStates", r.URL.Path)
+		if calls >= len(states) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, states[calls])
+		calls++
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	w := NewWatcher(client, 12345)
+	w.Interval = time.Millisecond
+	w.Jitter = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []Event
+	for e := range w.Watch(ctx) {
+		got = append(got, e)
+		if e.Type == EventError {
+			cancel()
+		}
+	}
+
+	type zoneEvent struct {
+		ZoneID int
+		Type   EventType
+	}
+	var ze []zoneEvent
+	for _, e := range got {
+		assert.Equal(t, 12345, e.HomeID)
+		ze = append(ze, zoneEvent{e.ZoneID, e.Type})
+	}
+	assert.Equal(t, []zoneEvent{
+		{1, EventTemperatureChanged},
+		{1, EventSetpointChanged},
+		{1, EventOverlayStarted},
+		{1, EventHeatingPowerChanged},
+		{2, EventLinkOffline},
+		{2, EventOpenWindowDetected},
+		{0, EventError},
+	}, ze)
+
+	if assert.Len(t, got, 7) {
+		assert.Equal(t, 19.0, got[1].Previous.Setting.Temperature.Celsius)
+		assert.Equal(t, 21.0, got[1].Current.Setting.Temperature.Celsius)
+		assert.Error(t, got[6].Err)
+	}
+}
+
+func TestWatcher_WatchConcurrent(t *testing.T) {
+	defer func(d time.Duration) { minWatchInterval = d }(minWatchInterval)
+	minWatchInterval = time.Millisecond
+
+	var mu sync.Mutex
+	calls := 0
+	f := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		_, _ = fmt.Fprintf(w, `{"zoneStates": {"1": {"sensorDataPoints": {"insideTemperature": {"celsius": %d}}}, "2": {}}}`, calls)
+	}
+
+	client, server := setupTestClientAndServer(f)
+	defer server.Close()
+
+	w := NewWatcher(client, 12345)
+	w.Interval = time.Millisecond
+	w.Jitter = 0
+
+	// one Watcher can be used by multiple goroutines
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		events := w.Watch(ctx)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 3; j++ {
+				e := <-events
+				assert.Equal(t, EventTemperatureChanged, e.Type)
+				assert.Equal(t, 1, e.ZoneID)
+			}
+			cancel()
+			// wait until polling stopped
+			for range events {
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWatcher_nextInterval(t *testing.T) {
+	w := NewWatcher(nil, 1)
+	w.Jitter = 0
+
+	assert.Equal(t, DefaultWatchInterval, w.nextInterval())
+
+	w.Interval = time.Second
+	assert.Equal(t, minWatchInterval, w.nextInterval())
+
+	// a budget of 144 requests per day allows one poll every 10 minutes
+	w.RequestsPerDay = 144
+	assert.Equal(t, 10*time.Minute, w.nextInterval())
+
+	w.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := w.nextInterval()
+		assert.True(t, d >= 10*time.Minute && d <= 15*time.Minute, "interval %s out of range", d)
+	}
+}
+
+func TestZoneStateChanges_Overlay(t *testing.T) {
+	expiry := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	timer := ZoneState{
+		OverlayType: OverlayTypeManual,
+		Overlay: Overlay{
+			Type:        OverlayTypeManual,
+			Setting:     Setting{Type: ZoneTypeHeating, Power: PowerOn, Temperature: Temperature{Celsius: 21}},
+			Termination: Termination{Type: TerminationTypeTimer, DurationInSeconds: 3600, Expiry: expiry, RemainingTimeInSeconds: 1800},
+		},
+	}
+	timer.Setting = timer.Overlay.Setting
+
+	assert.Equal(t, []EventType{EventSetpointChanged, EventOverlayStarted}, zoneStateChanges(ZoneState{}, timer))
+	assert.Equal(t, []EventType{EventSetpointChanged, EventOverlayEnded}, zoneStateChanges(timer, ZoneState{}))
+
+	// a timer that is running is not a new overlay
+	running := timer
+	running.Overlay.Termination.RemainingTimeInSeconds = 1200
+	assert.Empty(t, zoneStateChanges(timer, running))
+
+	// an overlay replaced by another one starts a new overlay
+	restarted := timer
+	restarted.Overlay.Termination.Expiry = expiry.Add(time.Hour)
+	assert.Equal(t, []EventType{EventOverlayStarted}, zoneStateChanges(timer, restarted))
+
+	off := timer
+	off.Overlay.Setting = Setting{Type: ZoneTypeHeating, Power: PowerOff}
+	off.Overlay.Termination = Termination{Type: TerminationTypeManual}
+	off.Setting = off.Overlay.Setting
+	assert.Equal(t, []EventType{EventSetpointChanged, EventOverlayStarted}, zoneStateChanges(timer, off))
+}